@@ -0,0 +1,82 @@
+package activity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// Do executes the http request and populates v with the result
+//
+// A response with an error status is returned as the Fault created by newFault.
+func Do[F Fault](client *http.Client, req *http.Request, v any, newFault func(*http.Response) F) error {
+	res, err := Send(client, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return Decode(res, v, newFault)
+}
+
+// Download executes the http request and returns the body of the response as a file
+//
+// The file is named by the filename of the response's Content-Disposition header if present,
+// otherwise by `name`. A response with an error status is returned as the Fault created by
+// newFault.
+func Download[F Fault](
+	client *http.Client, req *http.Request, name string, format Format, newFault func(*http.Response) F) (*File, error) {
+	res, err := Send(client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, newFault(res)
+	}
+	var buf bytes.Buffer
+	if _, err = io.Copy(&buf, res.Body); err != nil {
+		return nil, err
+	}
+	if disposition := res.Header.Get("Content-Disposition"); disposition != "" {
+		_, params, perr := mime.ParseMediaType(disposition)
+		if perr == nil && params["filename"] != "" {
+			name = params["filename"]
+		}
+	}
+	return &File{Reader: &buf, Name: name, Format: format}, nil
+}
+
+// Send executes the http request returning the context's error if the request was canceled
+func Send(client *http.Client, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	res, err := client.Do(req)
+	if err != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Decode populates v with the result of the response
+//
+// A response with an error status is returned as the Fault created by newFault.
+func Decode[F Fault](res *http.Response, v any, newFault func(*http.Response) F) error {
+	if res.StatusCode >= http.StatusBadRequest {
+		return newFault(res)
+	}
+	if v == nil {
+		return nil
+	}
+	err := json.NewDecoder(res.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		err = nil // ignore EOF errors caused by empty response body
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package activity_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+)
+
+type clientFault struct {
+	activity.FaultStatus
+	Message string `json:"message"`
+}
+
+func (f *clientFault) Error() string {
+	return f.Message
+}
+
+func newClientFault(res *http.Response) *clientFault {
+	f := &clientFault{}
+	f.FaultStatus = activity.DecodeFault(res, f)
+	return f
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id":12}`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"no such thing"}`))
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	tests := []struct {
+		name string
+		path string
+		ctx  func() context.Context
+		id   int
+		err  error
+	}{
+		{name: "ok", path: "/ok", id: 12},
+		{name: "empty", path: "/empty"},
+		{name: "fault", path: "/missing", err: activity.ErrNotFound},
+		{
+			name: "canceled",
+			path: "/ok",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			err: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, svr.URL+tt.path, nil)
+			a.NoError(err)
+			var v struct {
+				ID int `json:"id"`
+			}
+			err = activity.Do(svr.Client(), req, &v, newClientFault)
+			if tt.err != nil {
+				a.ErrorIs(err, tt.err)
+				var fault *clientFault
+				if errors.As(err, &fault) {
+					a.Equal("no such thing", fault.Error())
+				}
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.id, v.ID)
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/named", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="Morning Ride.gpx"`)
+		_, _ = w.Write([]byte("<gpx/>"))
+	})
+	mux.HandleFunc("/unnamed", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<gpx/>"))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	tests := []struct {
+		path string
+		name string
+		err  error
+	}{
+		{path: "/named", name: "Morning Ride.gpx"},
+		{path: "/unnamed", name: "94.gpx"},
+		{path: "/missing", err: activity.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, svr.URL+tt.path, nil)
+			a.NoError(err)
+			file, err := activity.Download(svr.Client(), req, "94.gpx", activity.FormatGPX, newClientFault)
+			if tt.err != nil {
+				a.ErrorIs(err, tt.err)
+				a.Nil(file)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.name, file.Name)
+			a.Equal(activity.FormatGPX, file.Format)
+			b, err := io.ReadAll(file)
+			a.NoError(err)
+			a.Equal("<gpx/>", string(b))
+		})
+	}
+}
@@ -1,6 +1,6 @@
 package cyclinganalytics
 
-//go:generate genwith --do --client --endpoint-func --config --token --ratelimit --package cyclinganalytics
+//go:generate genwith --client --endpoint-func --config --token --ratelimit --package cyclinganalytics
 
 import (
 	"context"
@@ -85,3 +85,23 @@ func (c *Client) newAPIRequest(
 func (c *Client) Uploader() activity.Uploader {
 	return newUploader(c.Rides)
 }
+
+// do executes the http request and populates v with the result
+//
+// A response with an error status is returned as a *Fault.
+func (c *Client) do(req *http.Request, v any) error {
+	return activity.Do(c.client, req, v, newFault)
+}
+
+// newFault returns a Fault from the error response
+func newFault(res *http.Response) *Fault {
+	fault := &Fault{}
+	fault.FaultStatus = activity.DecodeFault(res, fault)
+	if fault.Code == 0 {
+		fault.Code = res.StatusCode
+	}
+	if fault.Message == "" {
+		fault.Message = http.StatusText(res.StatusCode)
+	}
+	return fault
+}
@@ -57,11 +57,19 @@ func (r *Ride) GPX() (*gpx.GPX, error) {
 	trk := gpx.NewTrkType(mls)
 	trk.Src = _baseURL
 
-	return &gpx.GPX{
+	x := &gpx.GPX{
 		Creator: activity.UserAgent,
 		Metadata: &gpx.MetadataType{
 			Name: strconv.FormatInt(r.ID, 10),
 		},
 		Trk: []*gpx.TrkType{trk},
-	}, nil
+	}
+	sensors := &activity.SensorStreams{
+		HeartRate:   s.Heartrate,
+		Cadence:     s.Cadence,
+		Temperature: s.Temperature,
+		Power:       s.Power,
+	}
+	sensors.Encode(x, trk.TrkSeg[0].TrkPt)
+	return x, nil
 }
@@ -29,3 +29,24 @@ func TestRideGPX(t *testing.T) {
 	a.Equal(5, len(gpx.Trk[0].TrkSeg[0].TrkPt))
 	a.Equal(0, len(gpx.Rte))
 }
+
+func TestRideGPXSensors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/ride.json")
+	a.NoError(err)
+	var ride cyclinganalytics.Ride
+	a.NoError(json.Unmarshal(data, &ride))
+	ride.Streams.Power = []float64{180, 190, 200, 210, 220}
+	ride.Streams.Heartrate = []float64{130, 131, 132, 133, 134}
+
+	gpx, err := ride.GPX()
+	a.NoError(err)
+	a.NotNil(gpx)
+	pts := gpx.Trk[0].TrkSeg[0].TrkPt
+	a.Equal(5, len(pts))
+	a.Contains(string(pts[4].Extensions.XML), "<gpxtpx:hr>134</gpxtpx:hr>")
+	a.Contains(string(pts[4].Extensions.XML), "<pwr:Watts>220</pwr:Watts>")
+	a.Contains(gpx.XMLAttrs, "xmlns:gpxtpx")
+	a.Contains(gpx.XMLAttrs, "xmlns:pwr")
+}
@@ -1,6 +1,9 @@
 package cyclinganalytics
 
 import (
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bzimmer/activity"
@@ -8,6 +11,7 @@ import (
 
 // Fault represents an error response
 type Fault struct { //nolint:errname // convention
+	activity.FaultStatus
 	Code    int    `json:"code"`
 	Message string `json:"error"`
 }
@@ -173,6 +177,31 @@ func (u *Upload) Done() bool {
 	return u.Status != "processing"
 }
 
+// rideIDRE matches the existing ride id in messages such as "The ride already exists: 500000000005"
+var rideIDRE = regexp.MustCompile(`(\d+)\s*$`)
+
+// Outcome classifies the outcome of the upload
+func (u *Upload) Outcome() *activity.UploadOutcome {
+	switch u.Status {
+	case "processing":
+		return &activity.UploadOutcome{Status: activity.UploadPending}
+	case "done":
+		return &activity.UploadOutcome{Status: activity.UploadCreated, ActivityID: u.RideID}
+	}
+	switch {
+	case u.ErrorCode == "duplicate_ride":
+		outcome := &activity.UploadOutcome{Status: activity.UploadDuplicate, ActivityID: u.RideID, Message: u.Error}
+		if m := rideIDRE.FindStringSubmatch(u.Error); outcome.ActivityID == 0 && m != nil {
+			outcome.ActivityID, _ = strconv.ParseInt(m[1], 10, 64)
+		}
+		return outcome
+	case strings.Contains(u.ErrorCode, "file"), strings.Contains(u.ErrorCode, "format"):
+		return &activity.UploadOutcome{Status: activity.UploadInvalid, Message: u.Error}
+	default:
+		return &activity.UploadOutcome{Status: activity.UploadFailed, Message: u.Error}
+	}
+}
+
 type UploadResult struct {
 	Upload *Upload `json:"upload"`
 	Err    error   `json:"error"`
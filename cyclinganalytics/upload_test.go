@@ -154,3 +154,37 @@ func TestUploadIdentifier(t *testing.T) {
 	u.Status = "done"
 	a.True(u.Done())
 }
+
+func TestUploadOutcome(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name       string
+		upload     *cyclinganalytics.Upload
+		status     activity.UploadStatus
+		activityID int64
+	}{
+		{name: "processing", upload: &cyclinganalytics.Upload{Status: "processing"}, status: activity.UploadPending},
+		{name: "done", upload: &cyclinganalytics.Upload{Status: "done", RideID: 500000000001},
+			status: activity.UploadCreated, activityID: 500000000001},
+		{name: "duplicate", upload: &cyclinganalytics.Upload{
+			Status: "error", ErrorCode: "duplicate_ride", Error: "The ride already exists: 500000000005"},
+			status: activity.UploadDuplicate, activityID: 500000000005},
+		{name: "invalid", upload: &cyclinganalytics.Upload{
+			Status: "error", ErrorCode: "invalid_file", Error: "The file could not be read"},
+			status: activity.UploadInvalid},
+		{name: "failed", upload: &cyclinganalytics.Upload{
+			Status: "error", ErrorCode: "internal_error", Error: "An error occurred"},
+			status: activity.UploadFailed},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			outcome := activity.Outcome(tt.upload)
+			a.Equal(tt.status, outcome.Status)
+			a.Equal(tt.activityID, outcome.ActivityID)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cyclinganalytics_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bzimmer/activity"
 	"github.com/bzimmer/activity/cyclinganalytics"
 )
 
@@ -51,3 +53,32 @@ func TestUser(t *testing.T) {
 		})
 	}
 }
+
+func TestFaultErrors(t *testing.T) {
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/me", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":"slow down"}`))
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	client, err := cyclinganalytics.NewClient(
+		cyclinganalytics.WithBaseURL(svr.URL),
+		cyclinganalytics.WithTokenCredentials("fooKey", "barToken", time.Time{}))
+	a.NoError(err)
+	me, err := client.User.Me(context.Background())
+	a.Nil(me)
+	a.ErrorIs(err, activity.ErrRateLimited)
+	a.False(errors.Is(err, activity.ErrNotFound))
+	var fault *cyclinganalytics.Fault
+	a.True(errors.As(err, &fault))
+	a.Equal("slow down", fault.Error())
+	a.Equal(http.StatusTooManyRequests, fault.HTTPStatus())
+	wait, ok := activity.RetryAfter(err)
+	a.True(ok)
+	a.Equal(time.Minute, wait)
+}
@@ -0,0 +1,98 @@
+package activity
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/twpayne/go-gpx"
+)
+
+const (
+	trackPointExtensionNS  = "http://www.garmin.com/xmlschemas/TrackPointExtension/v2"
+	trackPointExtensionXSD = "https://www8.garmin.com/xmlschemas/TrackPointExtensionv2.xsd"
+	powerExtensionNS       = "http://www.garmin.com/xmlschemas/PowerExtension/v1"
+	powerExtensionXSD      = "https://www8.garmin.com/xmlschemas/PowerExtensionv1.xsd"
+)
+
+// SensorStreams are streams of sensor data aligned by index with the points of a GPX track or route
+//
+// A nil or short stream is ignored for the points it does not cover.
+type SensorStreams struct {
+	// HeartRate in beats per minute
+	HeartRate []float64
+	// Cadence in revolutions per minute
+	Cadence []float64
+	// Temperature in degrees celsius
+	Temperature []float64
+	// Power in watts
+	Power []float64
+	// Speed in meters per second
+	Speed []float64
+}
+
+// Encode adds the Garmin TrackPointExtension (v2) and PowerExtension (v1) to the points and
+// declares the extension namespaces on the document
+func (s *SensorStreams) Encode(x *gpx.GPX, points []*gpx.WptType) {
+	var hasTPX, hasPWR bool
+	for i, pt := range points {
+		tpx, pwr := s.trackPointExtension(i), s.powerExtension(i)
+		if tpx == "" && pwr == "" {
+			continue
+		}
+		pt.Extensions = &gpx.ExtensionsType{XML: []byte(tpx + pwr)}
+		hasTPX = hasTPX || tpx != ""
+		hasPWR = hasPWR || pwr != ""
+	}
+	if !hasTPX && !hasPWR {
+		return
+	}
+	if x.XMLAttrs == nil {
+		x.XMLAttrs = make(map[string]string)
+	}
+	if hasTPX {
+		x.XMLAttrs["xmlns:gpxtpx"] = trackPointExtensionNS
+		x.XMLSchemaLocations = append(x.XMLSchemaLocations, trackPointExtensionNS, trackPointExtensionXSD)
+	}
+	if hasPWR {
+		x.XMLAttrs["xmlns:pwr"] = powerExtensionNS
+		x.XMLSchemaLocations = append(x.XMLSchemaLocations, powerExtensionNS, powerExtensionXSD)
+	}
+}
+
+func (s *SensorStreams) trackPointExtension(i int) string {
+	var sb strings.Builder
+	// the schema requires the elements in the order: atemp, hr, cad, speed
+	if v, ok := at(s.Temperature, i); ok {
+		sb.WriteString("<gpxtpx:atemp>" + strconv.FormatFloat(v, 'f', -1, 64) + "</gpxtpx:atemp>")
+	}
+	if v, ok := at(s.HeartRate, i); ok {
+		sb.WriteString("<gpxtpx:hr>" + strconv.FormatFloat(math.Round(v), 'f', 0, 64) + "</gpxtpx:hr>")
+	}
+	if v, ok := at(s.Cadence, i); ok {
+		sb.WriteString("<gpxtpx:cad>" + strconv.FormatFloat(math.Round(v), 'f', 0, 64) + "</gpxtpx:cad>")
+	}
+	if v, ok := at(s.Speed, i); ok {
+		sb.WriteString("<gpxtpx:speed>" + strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64) + "</gpxtpx:speed>")
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "<gpxtpx:TrackPointExtension>" + sb.String() + "</gpxtpx:TrackPointExtension>"
+}
+
+func (s *SensorStreams) powerExtension(i int) string {
+	v, ok := at(s.Power, i)
+	if !ok {
+		return ""
+	}
+	return "<pwr:PowerExtension><pwr:Watts>" +
+		strconv.FormatFloat(math.Round(v), 'f', 0, 64) + "</pwr:Watts></pwr:PowerExtension>"
+}
+
+func at(data []float64, i int) (float64, bool) {
+	if i < 0 || i >= len(data) {
+		return 0, false
+	}
+	return data[i], true
+}
@@ -0,0 +1,96 @@
+package activity_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-gpx"
+
+	"github.com/bzimmer/activity"
+)
+
+func TestSensorStreams(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name    string
+		streams *activity.SensorStreams
+		ext     []string
+		attrs   []string
+	}{
+		{
+			name:    "no streams",
+			streams: &activity.SensorStreams{},
+			ext:     []string{"", ""},
+		},
+		{
+			name: "all streams",
+			streams: &activity.SensorStreams{
+				HeartRate:   []float64{120.4, 130},
+				Cadence:     []float64{88, 90.6},
+				Temperature: []float64{21.5, 21},
+				Power:       []float64{250, 275},
+			},
+			ext: []string{
+				"<gpxtpx:TrackPointExtension><gpxtpx:atemp>21.5</gpxtpx:atemp><gpxtpx:hr>120</gpxtpx:hr>" +
+					"<gpxtpx:cad>88</gpxtpx:cad></gpxtpx:TrackPointExtension>" +
+					"<pwr:PowerExtension><pwr:Watts>250</pwr:Watts></pwr:PowerExtension>",
+				"<gpxtpx:TrackPointExtension><gpxtpx:atemp>21</gpxtpx:atemp><gpxtpx:hr>130</gpxtpx:hr>" +
+					"<gpxtpx:cad>91</gpxtpx:cad></gpxtpx:TrackPointExtension>" +
+					"<pwr:PowerExtension><pwr:Watts>275</pwr:Watts></pwr:PowerExtension>",
+			},
+			attrs: []string{"xmlns:gpxtpx", "xmlns:pwr"},
+		},
+		{
+			name: "speed stream",
+			streams: &activity.SensorStreams{
+				Cadence: []float64{88},
+				Speed:   []float64{8.3333, 0},
+			},
+			ext: []string{
+				"<gpxtpx:TrackPointExtension><gpxtpx:cad>88</gpxtpx:cad>" +
+					"<gpxtpx:speed>8.33</gpxtpx:speed></gpxtpx:TrackPointExtension>",
+				"<gpxtpx:TrackPointExtension><gpxtpx:speed>0</gpxtpx:speed></gpxtpx:TrackPointExtension>",
+			},
+			attrs: []string{"xmlns:gpxtpx"},
+		},
+		{
+			name: "short power stream",
+			streams: &activity.SensorStreams{
+				Power: []float64{250},
+			},
+			ext: []string{
+				"<pwr:PowerExtension><pwr:Watts>250</pwr:Watts></pwr:PowerExtension>",
+				"",
+			},
+			attrs: []string{"xmlns:pwr"},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			points := []*gpx.WptType{{Lat: 47.6, Lon: -122.3}, {Lat: 47.7, Lon: -122.4}}
+			x := &gpx.GPX{Trk: []*gpx.TrkType{{TrkSeg: []*gpx.TrkSegType{{TrkPt: points}}}}}
+			tt.streams.Encode(x, points)
+			for j := range tt.ext {
+				if tt.ext[j] == "" {
+					a.Nil(points[j].Extensions)
+					continue
+				}
+				a.Equal(tt.ext[j], string(points[j].Extensions.XML))
+			}
+			a.Len(x.XMLAttrs, len(tt.attrs))
+			for _, attr := range tt.attrs {
+				a.Contains(x.XMLAttrs, attr)
+			}
+			var buf bytes.Buffer
+			a.NoError(x.Write(&buf))
+			y, err := gpx.Read(&buf)
+			a.NoError(err)
+			a.Len(y.Trk[0].TrkSeg[0].TrkPt, 2)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package activity
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrNotFound the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized the credentials are missing, invalid, or lack permission for the resource
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited the request exceeded the provider's rate limit
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInvalidRequest the provider rejected the request as malformed or invalid
+	ErrInvalidRequest = errors.New("invalid request")
+)
+
+// Fault is implemented by each provider's error response
+type Fault interface {
+	error
+	// HTTPStatus returns the status code of the response
+	HTTPStatus() int
+	// RetryAfter returns the duration to wait before retrying or zero if no hint was provided
+	RetryAfter() time.Duration
+}
+
+// FaultStatus holds the HTTP status and retry-after hint of an error response
+//
+// Provider Faults embed FaultStatus to implement Fault and support `errors.Is`,
+// leaving only the fields decoded from the response body to the provider.
+type FaultStatus struct {
+	status     int
+	retryAfter time.Duration
+}
+
+// DecodeFault decodes the body of the error response into fault and returns its FaultStatus
+//
+// The body is not always json so any decoding error is ignored in favor of the status.
+func DecodeFault(res *http.Response, fault any) FaultStatus {
+	_ = json.NewDecoder(res.Body).Decode(fault)
+	return FaultStatus{
+		status:     res.StatusCode,
+		retryAfter: ParseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// HTTPStatus returns the status code of the response
+func (f *FaultStatus) HTTPStatus() int {
+	return f.status
+}
+
+// RetryAfter returns the duration to wait before retrying or zero if no hint was provided
+func (f *FaultStatus) RetryAfter() time.Duration {
+	return f.retryAfter
+}
+
+// Is supports matching the sentinel errors with `errors.Is`
+func (f *FaultStatus) Is(target error) bool {
+	return IsStatusError(f.status, target)
+}
+
+// StatusError returns the sentinel error for the HTTP status or nil if none applies
+func StatusError(status int) error {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return ErrUnauthorized
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status >= http.StatusBadRequest && status < http.StatusInternalServerError:
+		return ErrInvalidRequest
+	default:
+		return nil
+	}
+}
+
+// IsStatusError reports whether the target is the sentinel error for the HTTP status
+//
+// Provider Faults use this to implement support for `errors.Is`.
+func IsStatusError(status int, target error) bool {
+	return target != nil && target == StatusError(status)
+}
+
+// RetryAfter returns the retry-after hint of the first Fault in the error's chain
+func RetryAfter(err error) (time.Duration, bool) {
+	var fault Fault
+	if !errors.As(err, &fault) {
+		return 0, false
+	}
+	d := fault.RetryAfter()
+	return d, d > 0
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, either delay seconds or an HTTP date
+//
+// Zero is returned if the value is empty, invalid, or in the past.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	t, err := http.ParseTime(value)
+	if err != nil || !t.After(now) {
+		return 0
+	}
+	return t.Sub(now)
+}
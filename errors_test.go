@@ -0,0 +1,144 @@
+package activity_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+)
+
+type fault struct {
+	status     int
+	retryAfter time.Duration
+}
+
+func (f *fault) Error() string {
+	return http.StatusText(f.status)
+}
+
+func (f *fault) HTTPStatus() int {
+	return f.status
+}
+
+func (f *fault) RetryAfter() time.Duration {
+	return f.retryAfter
+}
+
+func (f *fault) Is(target error) bool {
+	return activity.IsStatusError(f.status, target)
+}
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		status int
+		err    error
+	}{
+		{status: http.StatusOK, err: nil},
+		{status: http.StatusBadRequest, err: activity.ErrInvalidRequest},
+		{status: http.StatusUnauthorized, err: activity.ErrUnauthorized},
+		{status: http.StatusForbidden, err: activity.ErrUnauthorized},
+		{status: http.StatusNotFound, err: activity.ErrNotFound},
+		{status: http.StatusUnprocessableEntity, err: activity.ErrInvalidRequest},
+		{status: http.StatusTooManyRequests, err: activity.ErrRateLimited},
+		{status: http.StatusInternalServerError, err: nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(fmt.Sprintf("%d", tt.status), func(t *testing.T) {
+			t.Parallel()
+			a.Equal(tt.err, activity.StatusError(tt.status))
+			err := fmt.Errorf("wrapped: %w", &fault{status: tt.status})
+			if tt.err != nil {
+				a.ErrorIs(err, tt.err)
+			}
+			a.False(errors.Is(&fault{status: tt.status}, nil))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	d, ok := activity.RetryAfter(fmt.Errorf("wrapped: %w", &fault{status: 429, retryAfter: time.Minute}))
+	a.True(ok)
+	a.Equal(time.Minute, d)
+
+	d, ok = activity.RetryAfter(&fault{status: 429})
+	a.False(ok)
+	a.Zero(d)
+
+	d, ok = activity.RetryAfter(errors.New("not a fault"))
+	a.False(ok)
+	a.Zero(d)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	a.Equal(time.Duration(0), activity.ParseRetryAfter("", now))
+	a.Equal(120*time.Second, activity.ParseRetryAfter("120", now))
+	a.Equal(time.Duration(0), activity.ParseRetryAfter("-1", now))
+	a.Equal(time.Duration(0), activity.ParseRetryAfter("soon", now))
+	a.Equal(90*time.Second, activity.ParseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	a.Equal(time.Duration(0), activity.ParseRetryAfter(now.Add(-time.Hour).Format(http.TimeFormat), now))
+}
+
+func TestDecodeFault(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	type fault struct {
+		activity.FaultStatus
+		Message string `json:"message"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		header     http.Header
+		message    string
+		retryAfter time.Duration
+	}{
+		{
+			name:       "json",
+			body:       `{"message":"slow down"}`,
+			header:     http.Header{"Retry-After": []string{"10"}},
+			message:    "slow down",
+			retryAfter: 10 * time.Second,
+		},
+		{
+			name: "not json",
+			body: "<html>slow down</html>",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     tt.header,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			f := &fault{}
+			f.FaultStatus = activity.DecodeFault(res, f)
+			a.Equal(tt.message, f.Message)
+			a.Equal(http.StatusTooManyRequests, f.HTTPStatus())
+			a.Equal(tt.retryAfter, f.RetryAfter())
+			a.True(f.Is(activity.ErrRateLimited))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package rwgps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bzimmer/activity"
+)
+
+// CollectionsService provides access to collections via the RWGPS API
+type CollectionsService service
+
+// Collections returns a slice of collections of the user
+func (s *CollectionsService) Collections(
+	ctx context.Context, userID UserID, spec activity.Pagination) ([]*Collection, error) {
+	res := resource{
+		user: userID, uri: fmt.Sprintf("users/%d/collections.json", userID), v3: "collections.json", key: "collections"}
+	return paginate[*Collection](ctx, s.client, res, spec)
+}
+
+// Collection returns the collection for the `collectionID`
+func (s *CollectionsService) Collection(ctx context.Context, collectionID int64) (*Collection, error) {
+	uri := fmt.Sprintf("collections/%d.json", collectionID)
+	req, err := s.client.newAPIRequest(ctx, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	type CollectionResponse struct {
+		Collection *Collection `json:"collection"`
+	}
+	res := &CollectionResponse{}
+	if err = s.client.do(req, res); err != nil {
+		return nil, err
+	}
+	if res.Collection == nil {
+		return nil, errors.New("missing collection in response")
+	}
+	return res.Collection, nil
+}
+
+// Trips returns a slice of trips in the collection
+func (s *CollectionsService) Trips(
+	ctx context.Context, collectionID int64, spec activity.Pagination) ([]*Trip, error) {
+	return s.trips(ctx, collectionID, TypeTrip, spec)
+}
+
+// Routes returns a slice of routes in the collection
+func (s *CollectionsService) Routes(
+	ctx context.Context, collectionID int64, spec activity.Pagination) ([]*Trip, error) {
+	return s.trips(ctx, collectionID, TypeRoute, spec)
+}
+
+func (s *CollectionsService) trips(
+	ctx context.Context, collectionID int64, entity Type, spec activity.Pagination) ([]*Trip, error) {
+	res := resource{uri: fmt.Sprintf("collections/%d/%ss.json", collectionID, entity)}
+	trips, err := paginate[*Trip](ctx, s.client, res, spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, trip := range trips {
+		trip.Type = entity.String()
+	}
+	return trips, nil
+}
@@ -0,0 +1,68 @@
+package rwgps_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+	"github.com/bzimmer/activity/rwgps"
+)
+
+func TestCollections(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/users/1122/collections.json", func(w http.ResponseWriter, _ *http.Request) {
+			a.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"results":       []*rwgps.Collection{{ID: 1, Name: "Club Rides"}, {ID: 2, Name: "Gravel"}},
+				"results_count": 2,
+			}))
+		})
+		mux.HandleFunc("/collections/1.json", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"collection":{"id":1,"name":"Club Rides","user_id":1122}}`))
+		})
+		mux.HandleFunc("/collections/2.json", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		mux.HandleFunc("/collections/1/routes.json", func(w http.ResponseWriter, _ *http.Request) {
+			a.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"results": []*rwgps.Trip{{ID: 10}, {ID: 20}, {ID: 30}},
+			}))
+		})
+		mux.HandleFunc("/collections/1/trips.json", func(w http.ResponseWriter, _ *http.Request) {
+			a.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"results": []*rwgps.Trip{{ID: 110}},
+			}))
+		})
+	})
+	defer svr.Close()
+
+	ctx := context.Background()
+	collections, err := client.Collections.Collections(ctx, 1122, activity.Pagination{Total: 2})
+	a.NoError(err)
+	a.Len(collections, 2)
+	a.Equal("Gravel", collections[1].Name)
+
+	collection, err := client.Collections.Collection(ctx, 1)
+	a.NoError(err)
+	a.Equal(rwgps.UserID(1122), collection.UserID)
+
+	collection, err = client.Collections.Collection(ctx, 2)
+	a.ErrorIs(err, activity.ErrNotFound)
+	a.Nil(collection)
+
+	routes, err := client.Collections.Routes(ctx, 1, activity.Pagination{Total: 2})
+	a.NoError(err)
+	a.Len(routes, 2)
+	a.Equal(rwgps.TypeRoute.String(), routes[0].Type)
+
+	trips, err := client.Collections.Trips(ctx, 1, activity.Pagination{Total: 1})
+	a.NoError(err)
+	a.Len(trips, 1)
+	a.Equal(rwgps.TypeTrip.String(), trips[0].Type)
+}
@@ -3,7 +3,7 @@ package rwgps
 import (
 	"strconv"
 
-	"github.com/twpayne/go-geom"
+	"github.com/martinlindhe/unit"
 	"github.com/twpayne/go-gpx"
 
 	"github.com/bzimmer/activity"
@@ -11,53 +11,122 @@ import (
 
 var _ activity.GPXEncoder = (*Trip)(nil)
 
+// GPX encodes the trip as a GPX track or the route as a GPX route
+//
+// Trips include the time and sensor data (heart rate, cadence, power and speed) of each point;
+// grade is not encoded since it is derived from the elevation and distance.
 func (t *Trip) GPX() (*gpx.GPX, error) {
-	var layout geom.Layout
+	name := t.Name
+	if name == "" {
+		name = strconv.FormatInt(t.ID, 10)
+	}
+	x := &gpx.GPX{
+		Creator: activity.UserAgent,
+		Metadata: &gpx.MetadataType{
+			Name: name,
+			Desc: t.Description,
+		},
+	}
+	pts := make([]*gpx.WptType, len(t.TrackPoints))
+	for i, tp := range t.TrackPoints {
+		pts[i] = &gpx.WptType{
+			Lat: tp.Latitude,
+			Lon: tp.Longitude,
+			Ele: tp.Elevation.Meters(),
+		}
+	}
 	switch t.Type {
 	case TypeTrip.String():
-		layout = geom.XYZM
+		x.Metadata.Time = t.DepartedAt
+		for i, tp := range t.TrackPoints {
+			if tp.Time > 0 {
+				pts[i].Time = gpx.MToTime(tp.Time)
+			}
+		}
+		x.Trk = []*gpx.TrkType{{
+			Name:   name,
+			Desc:   t.Description,
+			TrkSeg: []*gpx.TrkSegType{{TrkPt: pts}},
+		}}
+		t.sensors().Encode(x, pts)
 	case TypeRoute.String():
 		// routes do not have a `time` dimension
-		layout = geom.XYZ
+		x.Rte = []*gpx.RteType{{
+			Name:  name,
+			Desc:  t.Description,
+			RtePt: pts,
+		}}
 	}
+	t.cues(x)
+	return x, nil
+}
 
-	n := len(t.TrackPoints)
-	dim := layout.Stride()
-	coords := make([]float64, dim*n)
-	for i, tp := range t.TrackPoints {
-		x := dim * i
-		coords[x+0] = tp.Longitude
-		coords[x+1] = tp.Latitude
-		switch layout {
-		case geom.XYZM:
-			coords[x+3] = tp.Time
-			fallthrough
-		case geom.XYZ:
-			coords[x+2] = tp.Elevation.Meters()
-		case geom.NoLayout, geom.XY, geom.XYM:
-			// pass
+// cues adds the course points and points of interest to the GPX
+//
+// Course points name the route point at the cue's index so head units display turn-by-turn
+// cues; if there is no route point at the index the cue is added as a waypoint. Points of
+// interest are always waypoints.
+func (t *Trip) cues(x *gpx.GPX) {
+	var rtept []*gpx.WptType
+	if len(x.Rte) > 0 {
+		rtept = x.Rte[0].RtePt
+	}
+	for _, cp := range t.CoursePoints {
+		if cp.Index >= 0 && cp.Index < len(rtept) {
+			pt := rtept[cp.Index]
+			pt.Name, pt.Type, pt.Cmt, pt.Desc = cp.Notes, cp.Type, cp.Type, cp.Description
+			continue
 		}
+		x.Wpt = append(x.Wpt, &gpx.WptType{
+			Lat:  cp.Latitude,
+			Lon:  cp.Longitude,
+			Name: cp.Notes,
+			Cmt:  cp.Type,
+			Desc: cp.Description,
+			Type: cp.Type,
+		})
 	}
+	for _, poi := range t.PointsOfInterest {
+		wpt := &gpx.WptType{
+			Lat:  poi.Latitude,
+			Lon:  poi.Longitude,
+			Name: poi.Name,
+			Desc: poi.Description,
+			Type: "poi",
+		}
+		if poi.URL != "" {
+			wpt.Link = []*gpx.LinkType{{HREF: poi.URL}}
+		}
+		x.Wpt = append(x.Wpt, wpt)
+	}
+}
 
-	x := &gpx.GPX{
-		Creator: activity.UserAgent,
-		Metadata: &gpx.MetadataType{
-			Name: strconv.FormatInt(t.ID, 10),
-		},
+func (t *Trip) sensors() *activity.SensorStreams {
+	n := len(t.TrackPoints)
+	hr, cadence, power, speed := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	var hasHR, hasCadence, hasPower, hasSpeed bool
+	for i, tp := range t.TrackPoints {
+		// the speed is decoded as is from the api so it is in kph despite the type
+		hr[i], cadence[i], power[i] = tp.HeartRate, tp.Cadence, tp.Power
+		speed[i] = (tp.Speed * unit.KilometersPerHour).MetersPerSecond()
+		hasHR = hasHR || tp.HeartRate > 0
+		hasCadence = hasCadence || tp.Cadence > 0
+		hasPower = hasPower || tp.Power > 0
+		hasSpeed = hasSpeed || tp.Speed > 0
 	}
-	switch layout {
-	case geom.XYZM:
-		ls := geom.NewLineStringFlat(layout, coords)
-		mls := geom.NewMultiLineString(ls.Layout())
-		if err := mls.Push(ls); err != nil {
-			return nil, err
-		}
-		x.Trk = []*gpx.TrkType{gpx.NewTrkType(mls)}
-	case geom.XYZ:
-		ls := geom.NewLineStringFlat(layout, coords)
-		x.Rte = []*gpx.RteType{gpx.NewRteType(ls)}
-	case geom.NoLayout, geom.XY, geom.XYM:
-		// pass
+	// rwgps omits the sensor data if not recorded so only include a stream if at least one value exists
+	sensors := &activity.SensorStreams{}
+	if hasHR {
+		sensors.HeartRate = hr
 	}
-	return x, nil
+	if hasCadence {
+		sensors.Cadence = cadence
+	}
+	if hasPower {
+		sensors.Power = power
+	}
+	if hasSpeed {
+		sensors.Speed = speed
+	}
+	return sensors
 }
@@ -1,9 +1,14 @@
 package rwgps_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -37,6 +42,16 @@ func TestTripEncoding(t *testing.T) {
 				a.NoError(err)
 				a.NotNil(gpx)
 				a.Equal(1465, len(gpx.Trk[0].TrkSeg[0].TrkPt))
+				a.Equal("Peak To Peak", gpx.Metadata.Name)
+				a.Equal(trip.Description, gpx.Metadata.Desc)
+				a.Equal("Peak To Peak", gpx.Trk[0].Name)
+				pt := gpx.Trk[0].TrkSeg[0].TrkPt[1]
+				a.Equal(time.Unix(1216570740, 0).UTC(), pt.Time)
+				a.Equal(148.0, trip.TrackPoints[1].HeartRate)
+				a.Equal("<gpxtpx:TrackPointExtension><gpxtpx:hr>148</gpxtpx:hr><gpxtpx:cad>86</gpxtpx:cad>"+
+					"<gpxtpx:speed>0</gpxtpx:speed></gpxtpx:TrackPointExtension>", string(pt.Extensions.XML))
+				a.Contains(gpx.XMLAttrs, "xmlns:gpxtpx")
+				a.NotContains(gpx.XMLAttrs, "xmlns:pwr")
 			},
 		},
 	}
@@ -51,3 +66,99 @@ func TestTripEncoding(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteEncoding(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/routes/141014.json", func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, "testdata/rwgps_route_141014.json")
+		})
+	})
+	defer svr.Close()
+	route, err := client.Trips.Route(context.TODO(), 141014)
+	a.NoError(err)
+	a.Len(route.CoursePoints, 32)
+	a.Len(route.PointsOfInterest, 3)
+	cp := route.CoursePoints[0]
+	a.Equal(2, cp.Index)
+	a.Equal("Right", cp.Type)
+	a.Equal("Test Description", cp.Description)
+	a.InDelta(556.989, cp.Distance.Meters(), 0.001)
+	poi := route.PointsOfInterest[0]
+	a.Equal("Porta Potty", poi.Name)
+	a.Equal(15, poi.Type)
+
+	gpx, err := route.GPX()
+	a.NoError(err)
+	rtept := gpx.Rte[0].RtePt
+	a.Len(rtept, 1154)
+	a.Equal("Take the 2nd right onto SW 45th Ave ", rtept[2].Name)
+	a.Equal("Right", rtept[2].Type)
+	a.Equal("Test Description", rtept[2].Desc)
+	a.Empty(rtept[1].Name)
+	a.Len(gpx.Wpt, 3)
+	a.Equal("Porta Potty", gpx.Wpt[0].Name)
+	a.Len(gpx.Wpt[1].Link, 1)
+
+	b, err := route.TCX()
+	a.NoError(err)
+	var db struct {
+		Course struct {
+			Name   string `xml:"Name"`
+			Points []struct {
+				Time      string                            `xml:"Time"`
+				Position  struct{ LatitudeDegrees float64 } `xml:"Position"`
+				Name      string                            `xml:"Name"`
+				PointType string                            `xml:"PointType"`
+				Notes     string                            `xml:"Notes"`
+			} `xml:"CoursePoint"`
+			Trackpoints []struct {
+				Time string `xml:"Time"`
+			} `xml:"Track>Trackpoint"`
+		} `xml:"Courses>Course"`
+	}
+	a.NoError(xml.Unmarshal(b, &db))
+	a.Equal("Cool ride aroun", db.Course.Name)
+	a.Len(db.Course.Trackpoints, 1154)
+	a.Len(db.Course.Points, 32)
+	a.Equal("Take the 2", db.Course.Points[0].Name)
+	a.Equal("Right", db.Course.Points[0].PointType)
+	a.Equal("Left", db.Course.Points[1].PointType)
+	a.Equal(db.Course.Trackpoints[2].Time, db.Course.Points[0].Time)
+	a.InDelta(45.47624, db.Course.Points[0].Position.LatitudeDegrees, 0.00001)
+
+	_, err = (&rwgps.Trip{}).TCX()
+	a.Error(err)
+}
+
+func TestTripSensors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var trip rwgps.Trip
+	a.NoError(json.Unmarshal([]byte(`{"id":1,"type":"trip","track_points":[
+		{"x":-122.3,"y":47.6,"e":10,"t":1600000000,"h":120,"p":250,"c":90,"s":36},
+		{"x":-122.4,"y":47.7,"e":11}]}`), &trip))
+	a.Equal(250.0, trip.TrackPoints[0].Power)
+
+	x, err := trip.GPX()
+	a.NoError(err)
+	a.Equal("1", x.Metadata.Name)
+	pts := x.Trk[0].TrkSeg[0].TrkPt
+	a.Equal(time.Unix(1600000000, 0).UTC(), pts[0].Time)
+	a.True(pts[1].Time.IsZero())
+	a.Equal("<gpxtpx:TrackPointExtension><gpxtpx:hr>120</gpxtpx:hr><gpxtpx:cad>90</gpxtpx:cad>"+
+		"<gpxtpx:speed>10</gpxtpx:speed></gpxtpx:TrackPointExtension>"+
+		"<pwr:PowerExtension><pwr:Watts>250</pwr:Watts></pwr:PowerExtension>", string(pts[0].Extensions.XML))
+	a.Contains(x.XMLAttrs, "xmlns:pwr")
+
+	var buf bytes.Buffer
+	a.NoError(x.Write(&buf))
+	a.Contains(buf.String(), "<time>2020-09-13T12:26:40Z</time>")
+
+	b, err := trip.TCX()
+	a.NoError(err)
+	a.Contains(strings.Join(strings.Fields(string(b)), ""), "<HeartRateBpm><Value>120</Value></HeartRateBpm><Cadence>90</Cadence>")
+}
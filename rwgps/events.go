@@ -0,0 +1,41 @@
+package rwgps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bzimmer/activity"
+)
+
+// EventsService provides access to events via the RWGPS API
+type EventsService service
+
+// Events returns a slice of events organized by the user
+func (s *EventsService) Events(ctx context.Context, userID UserID, spec activity.Pagination) ([]*Event, error) {
+	res := resource{user: userID, uri: fmt.Sprintf("users/%d/events.json", userID), v3: "events.json", key: "events"}
+	return paginate[*Event](ctx, s.client, res, spec)
+}
+
+// Event returns the event for the `eventID` including its routes
+func (s *EventsService) Event(ctx context.Context, eventID int64) (*Event, error) {
+	uri := fmt.Sprintf("events/%d.json", eventID)
+	req, err := s.client.newAPIRequest(ctx, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	type EventResponse struct {
+		Event *Event `json:"event"`
+	}
+	res := &EventResponse{}
+	if err = s.client.do(req, res); err != nil {
+		return nil, err
+	}
+	if res.Event == nil {
+		return nil, errors.New("missing event in response")
+	}
+	for _, route := range res.Event.Routes {
+		route.Type = TypeRoute.String()
+	}
+	return res.Event, nil
+}
@@ -0,0 +1,90 @@
+package rwgps_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+	"github.com/bzimmer/activity/rwgps"
+)
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name   string
+		before func(mux *http.ServeMux)
+		after  func(evt *rwgps.Event, err error)
+	}{
+		{
+			name: "valid event",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/events/42.json", func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte(`{"event":{
+						"id":42,"name":"Saturday Club Ride","location":"Portland, OR",
+						"starts_at":"2021-06-05T08:00:00-07:00","routes":[{"id":141014}]}}`))
+				})
+			},
+			after: func(evt *rwgps.Event, err error) {
+				a.NoError(err)
+				a.NotNil(evt)
+				a.Equal("Saturday Club Ride", evt.Name)
+				a.Equal(time.Date(2021, time.June, 5, 15, 0, 0, 0, time.UTC), evt.StartsAt.UTC())
+				a.Len(evt.Routes, 1)
+				a.Equal(rwgps.TypeRoute.String(), evt.Routes[0].Type)
+			},
+		},
+		{
+			name: "missing event",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/events/42.json", func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte(`{}`))
+				})
+			},
+			after: func(evt *rwgps.Event, err error) {
+				a.Error(err)
+				a.Nil(evt)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClient(tt.before)
+			defer svr.Close()
+			evt, err := client.Events.Event(context.TODO(), 42)
+			tt.after(evt, err)
+		})
+	}
+}
+
+func TestEventsPagination(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/api/v3/events.json", func(w http.ResponseWriter, r *http.Request) {
+			a.Equal("Bearer barToken", r.Header.Get("Authorization"))
+			switch r.URL.Query().Get("page") {
+			case "1":
+				http.ServeFile(w, r, "testdata/rwgps_v3_events.json")
+			default:
+				a.Fail("unexpected page", r.URL.Query().Get("page"))
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+	}, rwgps.WithAPIV3())
+	defer svr.Close()
+
+	events, err := client.Events.Events(context.Background(), 0, activity.Pagination{})
+	a.NoError(err)
+	a.Len(events, 1)
+	a.Equal(int64(201), events[0].ID)
+	a.Equal("Tuesday Night Ride", events[0].Name)
+}
@@ -0,0 +1,102 @@
+package rwgps
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bzimmer/activity"
+)
+
+// ExportFormat is a file format supported for downloading trips and routes
+type ExportFormat string
+
+const (
+	// ExportGPX GPX format
+	ExportGPX ExportFormat = "gpx"
+	// ExportTCX TCX format
+	ExportTCX ExportFormat = "tcx"
+	// ExportKML KML format
+	ExportKML ExportFormat = "kml"
+	// ExportFIT FIT format, the original file for trips recorded by a FIT device
+	ExportFIT ExportFormat = "fit"
+)
+
+// Format returns the activity.Format for the export format
+//
+// KML has no equivalent and is reported as activity.FormatOriginal.
+func (f ExportFormat) Format() activity.Format {
+	return activity.ToFormat(string(f))
+}
+
+func (f ExportFormat) valid() bool {
+	switch f {
+	case ExportGPX, ExportTCX, ExportKML, ExportFIT:
+		return true
+	default:
+		return false
+	}
+}
+
+type exporter struct {
+	s      *TripsService
+	entity Type
+	format ExportFormat
+}
+
+func newExporter(s *TripsService, entity Type, format ExportFormat) activity.Exporter {
+	return &exporter{s: s, entity: entity, format: format}
+}
+
+// Export exports the data file
+func (e *exporter) Export(ctx context.Context, id int64) (*activity.Export, error) {
+	return e.s.export(ctx, e.entity, id, e.format)
+}
+
+// ExportTrip downloads the native file of the trip in the format
+func (s *TripsService) ExportTrip(ctx context.Context, tripID int64, format ExportFormat) (*activity.Export, error) {
+	return s.export(ctx, TypeTrip, tripID, format)
+}
+
+// ExportRoute downloads the native file of the route in the format
+func (s *TripsService) ExportRoute(ctx context.Context, routeID int64, format ExportFormat) (*activity.Export, error) {
+	return s.export(ctx, TypeRoute, routeID, format)
+}
+
+func (s *TripsService) export(ctx context.Context, entity Type, id int64, format ExportFormat) (*activity.Export, error) {
+	if !format.valid() {
+		return nil, fmt.Errorf("unsupported format '%s'", format)
+	}
+	var kind string
+	switch entity {
+	case TypeTrip:
+		kind = "trips"
+	case TypeRoute:
+		kind = "routes"
+	default:
+		return nil, fmt.Errorf("unsupported type '%s'", entity)
+	}
+	if err := s.client.unsupported("export"); err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	for k, v := range s.client.credentials() {
+		q.Set(k, v)
+	}
+	uri := s.client.endpoint(fmt.Sprintf("%s/%d.%s", kind, id, format))
+	if len(q) > 0 {
+		uri = fmt.Sprintf("%s?%s", uri, q.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.client.authorize(req)
+	file, err := activity.Download(
+		s.client.client, req, fmt.Sprintf("%d.%s", id, format), format.Format(), newFault)
+	if err != nil {
+		return nil, err
+	}
+	return &activity.Export{File: file, ID: id}, nil
+}
@@ -0,0 +1,101 @@
+package rwgps_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+	"github.com/bzimmer/activity/rwgps"
+)
+
+func TestExporter(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name     string
+		route    bool
+		id       int64
+		format   rwgps.ExportFormat
+		filename string
+		fileFmt  activity.Format
+		err      error
+	}{
+		{name: "trip gpx", id: 94, format: rwgps.ExportGPX, filename: "Morning Ride.gpx", fileFmt: activity.FormatGPX},
+		{name: "trip fit", id: 94, format: rwgps.ExportFIT, filename: "Morning Ride.fit", fileFmt: activity.FormatFIT},
+		{name: "trip kml", id: 94, format: rwgps.ExportKML, filename: "Morning Ride.kml", fileFmt: activity.FormatOriginal},
+		{name: "route tcx", route: true, id: 141014, format: rwgps.ExportTCX, filename: "141014.tcx", fileFmt: activity.FormatTCX},
+		{name: "missing trip", id: 95, format: rwgps.ExportGPX, err: activity.ErrNotFound},
+		{name: "unsupported format", id: 94, format: "xml"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClient(func(mux *http.ServeMux) {
+				for _, ext := range []string{"gpx", "tcx", "kml", "fit"} {
+					mux.HandleFunc("/trips/94."+ext, func(w http.ResponseWriter, r *http.Request) {
+						a.Equal("fooKey", r.URL.Query().Get("apikey"))
+						a.Equal("barToken", r.URL.Query().Get("auth_token"))
+						w.Header().Set("Content-Disposition", `attachment; filename="Morning Ride.`+ext+`"`)
+						_, _ = w.Write([]byte("data:" + ext))
+					})
+				}
+				mux.HandleFunc("/routes/141014.tcx", func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte("data:tcx"))
+				})
+			})
+			defer svr.Close()
+			exporter := client.ExporterWithFormat(tt.format)
+			if tt.route {
+				exporter = client.RouteExporterWithFormat(tt.format)
+			}
+			exp, err := exporter.Export(context.Background(), tt.id)
+			if tt.filename == "" {
+				a.Error(err)
+				if tt.err != nil {
+					a.ErrorIs(err, tt.err)
+				}
+				a.Nil(exp)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.id, exp.ID)
+			a.Equal(tt.filename, exp.Name)
+			a.Equal(tt.fileFmt, exp.Format)
+			b, err := io.ReadAll(exp)
+			a.NoError(err)
+			a.Equal("data:"+string(tt.format), string(b))
+		})
+	}
+}
+
+func TestExporterDefaultFormat(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/trips/94.gpx", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("data:gpx"))
+		})
+		mux.HandleFunc("/routes/141014.gpx", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("data:gpx"))
+		})
+	})
+	defer svr.Close()
+
+	for id, exporter := range map[int64]activity.Exporter{
+		94:     client.Exporter(),
+		141014: client.RouteExporter(),
+	} {
+		exp, err := exporter.Export(context.Background(), id)
+		a.NoError(err)
+		a.Equal(activity.FormatGPX, exp.Format)
+		a.Equal(fmt.Sprintf("%d.gpx", id), exp.Name)
+	}
+}
@@ -0,0 +1,16 @@
+package rwgps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzimmer/activity"
+)
+
+// GearService provides access to gear via the RWGPS API
+type GearService service
+
+// Gear returns a slice of the user's gear
+func (s *GearService) Gear(ctx context.Context, userID UserID, spec activity.Pagination) ([]*Gear, error) {
+	return paginate[*Gear](ctx, s.client, resource{user: userID, uri: fmt.Sprintf("users/%d/gear.json", userID)}, spec)
+}
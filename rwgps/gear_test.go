@@ -0,0 +1,53 @@
+package rwgps_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+)
+
+func TestGear(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/users/1122/gear.json", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Offset string `json:"offset"`
+				Limit  string `json:"limit"`
+			}
+			a.NoError(json.NewDecoder(r.Body).Decode(&body))
+			var gear []map[string]any
+			switch body.Offset {
+			case "0":
+				gear = []map[string]any{
+					{"id": 1, "name": "Roubaix", "total_distance": 1234567.8, "visible": true},
+					{"id": 2, "name": "Rockhopper", "total_distance": 100.0},
+				}
+			case "2":
+				gear = []map[string]any{{"id": 3, "name": "Trainer", "exclude_from_totals": true}}
+			}
+			a.NoError(json.NewEncoder(w).Encode(map[string]any{"results": gear}))
+		})
+	})
+	defer svr.Close()
+
+	ctx := context.Background()
+	gear, err := client.Gear.Gear(ctx, 1122, activity.Pagination{Count: 2})
+	a.NoError(err)
+	a.Len(gear, 3)
+	a.Equal("Roubaix", gear[0].Name)
+	a.True(gear[0].Visible)
+	a.InDelta(1234.5678, gear[0].Distance.Kilometers(), 0.0001)
+	a.True(gear[2].ExcludeFromTotals)
+
+	gear, err = client.Gear.Gear(ctx, 1122, activity.Pagination{Total: 1})
+	a.NoError(err)
+	a.Len(gear, 1)
+	a.Equal(int64(1), gear[0].ID)
+}
@@ -28,6 +28,7 @@ const (
 
 // Fault is an error
 type Fault struct { //nolint:errname // convention
+	activity.FaultStatus
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
@@ -95,39 +96,131 @@ type TrackPoint struct {
 	Cadence   float64     `json:"c"`
 	Grade     float64     `json:"g"`
 	Speed     unit.Speed  `json:"s" units:"kph"`
+	HeartRate float64     `json:"h"`
+	Power     float64     `json:"p"`
+}
+
+// CoursePoint is a cue on the cue sheet of a route
+type CoursePoint struct {
+	Longitude   float64     `json:"x"`
+	Latitude    float64     `json:"y"`
+	Distance    unit.Length `json:"d" units:"m"`
+	Index       int         `json:"i"` // index of the track point of the cue
+	Type        string      `json:"t"` // eg Left, Right, Straight, Food, Water
+	Notes       string      `json:"n"`
+	Description string      `json:"description,omitempty"`
+}
+
+// PointOfInterest is a point of interest along a route
+type PointOfInterest struct {
+	ID          int64   `json:"id"`
+	Longitude   float64 `json:"lng"`
+	Latitude    float64 `json:"lat"`
+	URL         string  `json:"url,omitempty"`
+	Type        int     `json:"t"`
+	Name        string  `json:"n"`
+	Description string  `json:"d"`
 }
 
 // A Trip represents both a planned and completed activity
 type Trip struct {
-	CreatedAt     time.Time     `json:"created_at"`
-	DepartedAt    time.Time     `json:"departed_at"`
-	Description   string        `json:"description"`
-	Distance      unit.Length   `json:"distance" units:"m"`
-	Duration      int           `json:"duration"`
-	ElevationGain unit.Length   `json:"elevation_gain" units:"m"`
-	ElevationLoss unit.Length   `json:"elevation_loss" units:"m"`
-	ID            int64         `json:"id"`
-	Name          string        `json:"name"`
-	Type          string        `json:"type"`
-	TrackID       string        `json:"track_id"`
-	TrackPoints   []*TrackPoint `json:"track_points,omitempty"`
-	UpdatedAt     time.Time     `json:"updated_at"`
-	UserID        UserID        `json:"user_id"`
-	Visibility    int           `json:"visibility"`
-	FirstLat      float64       `json:"first_lat"`
-	FirstLng      float64       `json:"first_lng"`
-	LastLat       float64       `json:"last_lat"`
-	LastLng       float64       `json:"last_lng"`
-	Metrics       *Metrics      `json:"metrics,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	DepartedAt       time.Time          `json:"departed_at"`
+	Description      string             `json:"description"`
+	Distance         unit.Length        `json:"distance" units:"m"`
+	Duration         int                `json:"duration"`
+	ElevationGain    unit.Length        `json:"elevation_gain" units:"m"`
+	ElevationLoss    unit.Length        `json:"elevation_loss" units:"m"`
+	ID               int64              `json:"id"`
+	Name             string             `json:"name"`
+	Type             string             `json:"type"`
+	TrackID          string             `json:"track_id"`
+	TrackPoints      []*TrackPoint      `json:"track_points,omitempty"`
+	CoursePoints     []*CoursePoint     `json:"course_points,omitempty"`
+	PointsOfInterest []*PointOfInterest `json:"points_of_interest,omitempty"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	UserID           UserID             `json:"user_id"`
+	Visibility       int                `json:"visibility"`
+	FirstLat         float64            `json:"first_lat"`
+	FirstLng         float64            `json:"first_lng"`
+	LastLat          float64            `json:"last_lat"`
+	LastLng          float64            `json:"last_lng"`
+	Metrics          *Metrics           `json:"metrics,omitempty"`
+}
+
+// UpdatableTrip are the fields of a trip or route which can be updated
+type UpdatableTrip struct {
+	ID           int64   `json:"-"`
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	Visibility   *int    `json:"visibility,omitempty"`
+	GearID       *int64  `json:"gear_id,omitempty"`
+	ActivityType *string `json:"activity_type,omitempty"`
+}
+
+// Collection is a user's named collection of routes and trips
+type Collection struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UserID      UserID    `json:"user_id"`
+	Visibility  int       `json:"visibility"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Gear is a user's bike or other equipment
+type Gear struct {
+	ID                int64       `json:"id"`
+	Name              string      `json:"name"`
+	Nickname          string      `json:"nickname"`
+	Make              string      `json:"make"`
+	Model             string      `json:"model"`
+	Description       string      `json:"description"`
+	UserID            UserID      `json:"user_id"`
+	Distance          unit.Length `json:"total_distance" units:"m"` // mileage of all trips with the gear
+	Visible           bool        `json:"visible"`
+	ExcludeFromTotals bool        `json:"exclude_from_totals"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
 }
 
+// Event is an organized ride with its routes
+type Event struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UserID      UserID    `json:"user_id"`
+	Location    string    `json:"location"`
+	Latitude    float64   `json:"lat"`
+	Longitude   float64   `json:"lng"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Visibility  int       `json:"visibility"`
+	Routes      []*Trip   `json:"routes,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Task is a queued task processing an upload
 type Task struct {
 	ID        int    `json:"id"`
 	Message   string `json:"message"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 	UserID    int    `json:"user_id"`
-	Status    int    `json:"status"`
+	// Status is -1 for failed, 0 for pending, 1 for success
+	Status int `json:"status"`
+	// Trip is the trip created by the task, available only if objects were included in the request
+	Trip *Trip `json:"trip,omitempty"`
+}
+
+func (t *Task) Identifier() activity.UploadID {
+	return activity.UploadID(t.ID)
+}
+
+func (t *Task) Done() bool {
+	return t.Status != 0
 }
 
 // Upload is the state representation of an uploaded activity
@@ -155,7 +248,7 @@ func (u *Upload) Done() bool {
 		// this case is for any requests to the status endpoint
 		return u.Tasks[0].Status != 0
 	default:
-		// this case should not exist because status accepts only a single ID
+		// this case is for batch requests to the status endpoint
 		var ok = true
 		for i := 0; ok && i < n; i++ {
 			ok = ok && u.Tasks[i].Status != 0
@@ -163,3 +256,36 @@ func (u *Upload) Done() bool {
 		return ok
 	}
 }
+
+// Outcome classifies the outcome of the upload
+//
+// For status requests the outcome is that of the first task.
+func (u *Upload) Outcome() *activity.UploadOutcome {
+	if len(u.Tasks) == 0 {
+		// this case is for the initial enqueue on upload
+		switch u.Success {
+		case 0:
+			return &activity.UploadOutcome{Status: activity.UploadPending}
+		case 1:
+			return &activity.UploadOutcome{Status: activity.UploadCreated}
+		default:
+			return &activity.UploadOutcome{Status: activity.UploadFailed}
+		}
+	}
+	return u.Tasks[0].Outcome()
+}
+
+// Outcome classifies the outcome of the task
+func (t *Task) Outcome() *activity.UploadOutcome {
+	switch t.Status {
+	case 0:
+		return &activity.UploadOutcome{Status: activity.UploadPending, Message: t.Message}
+	case 1:
+		outcome := &activity.UploadOutcome{Status: activity.UploadCreated, Message: t.Message}
+		if t.Trip != nil {
+			outcome.ActivityID = t.Trip.ID
+		}
+		return outcome
+	}
+	return activity.FailedOutcome(t.Message)
+}
@@ -48,3 +48,40 @@ func TestUpload(t *testing.T) {
 		})
 	}
 }
+
+func TestUploadOutcome(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name       string
+		upload     *rwgps.Upload
+		status     activity.UploadStatus
+		activityID int64
+	}{
+		{name: "enqueued", upload: &rwgps.Upload{Success: 0}, status: activity.UploadPending},
+		{name: "enqueue success", upload: &rwgps.Upload{Success: 1}, status: activity.UploadCreated},
+		{name: "enqueue failed", upload: &rwgps.Upload{Success: -1}, status: activity.UploadFailed},
+		{name: "task pending", upload: &rwgps.Upload{Tasks: []*rwgps.Task{{Status: 0}}},
+			status: activity.UploadPending},
+		{name: "task success", upload: &rwgps.Upload{Tasks: []*rwgps.Task{{Status: 1}}},
+			status: activity.UploadCreated},
+		{name: "task duplicate", upload: &rwgps.Upload{Tasks: []*rwgps.Task{{Status: -1, Message: "Duplicate of trip 9876"}}},
+			status: activity.UploadDuplicate, activityID: 9876},
+		{name: "task duplicate without id", upload: &rwgps.Upload{Tasks: []*rwgps.Task{{Status: -1, Message: "duplicate trip"}}},
+			status: activity.UploadDuplicate},
+		{name: "task invalid", upload: &rwgps.Upload{Tasks: []*rwgps.Task{{Status: -1, Message: "Unsupported file type"}}},
+			status: activity.UploadInvalid},
+		{name: "task failed", upload: &rwgps.Upload{Tasks: []*rwgps.Task{{Status: -1, Message: "Something went wrong"}}},
+			status: activity.UploadFailed},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			outcome := activity.Outcome(tt.upload)
+			a.Equal(tt.status, outcome.Status)
+			a.Equal(tt.activityID, outcome.ActivityID)
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package rwgps
 
-//go:generate genwith --do --client --token --config --ratelimit --package rwgps
+//go:generate genwith --client --endpoint-func --config --token --ratelimit --package rwgps
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 
 	"golang.org/x/oauth2"
 
@@ -18,6 +22,8 @@ import (
 const (
 	apiVersion = "2"
 	_baseURL   = "https://ridewithgps.com"
+	// apiV3Prefix is the path prefix of the v3 API
+	apiV3Prefix = "api/v3"
 )
 
 // Client for communicating with RWGPS
@@ -26,19 +32,79 @@ type Client struct {
 	token   *oauth2.Token
 	client  *http.Client
 	baseURL string
+	v3      bool
 
-	Users *UsersService
-	Trips *TripsService
+	// mu guards me, the id of the authenticated user queried by the v3 api
+	mu sync.Mutex
+	me UserID
+
+	Users       *UsersService
+	Trips       *TripsService
+	Collections *CollectionsService
+	Gear        *GearService
+	Events      *EventsService
 }
 
+// Uploader returns an Uploader for this client
+//
+// The metadata of each uploaded file sets the name and description of the trip. The
+// Uploader is also an activity.BatchUploader so pollers check many uploads in one request.
 func (c *Client) Uploader() activity.Uploader {
 	return newUploader(c.Trips)
 }
 
+// Exporter returns an Exporter downloading trips in the GPX format
+func (c *Client) Exporter() activity.Exporter {
+	return c.ExporterWithFormat(ExportGPX)
+}
+
+// ExporterWithFormat returns an Exporter downloading trips in the format
+func (c *Client) ExporterWithFormat(format ExportFormat) activity.Exporter {
+	return newExporter(c.Trips, TypeTrip, format)
+}
+
+// RouteExporter returns an Exporter downloading routes in the GPX format
+func (c *Client) RouteExporter() activity.Exporter {
+	return c.RouteExporterWithFormat(ExportGPX)
+}
+
+// RouteExporterWithFormat returns an Exporter downloading routes in the format
+func (c *Client) RouteExporterWithFormat(format ExportFormat) activity.Exporter {
+	return newExporter(c.Trips, TypeRoute, format)
+}
+
+// Endpoint is RWGPS's OAuth 2.0 endpoint
+func Endpoint() oauth2.Endpoint {
+	return oauth2.Endpoint{
+		AuthURL:   "https://ridewithgps.com/oauth/authorize",
+		TokenURL:  "https://ridewithgps.com/oauth/token",
+		AuthStyle: oauth2.AuthStyleAutoDetect,
+	}
+}
+
+// WithAPIV3 uses the v3 API
+//
+// The v3 API authenticates with an OAuth 2.0 bearer token rather than the api key and
+// auth token of the legacy API. Its lists of trips, routes, events, and collections
+// belong to the authenticated user, so the user id passed to those services must be zero
+// or the id of the authenticated user, and are paginated by page with a size chosen by
+// the server. Gear, the trips and routes
+// of a collection, uploads, and exports are not served by the v3 API and return an error
+// wrapping `errors.ErrUnsupported`.
+func WithAPIV3() Option {
+	return func(c *Client) error {
+		c.v3 = true
+		return nil
+	}
+}
+
 func withServices() Option {
 	return func(c *Client) error {
 		c.Users = &UsersService{client: c}
 		c.Trips = &TripsService{client: c}
+		c.Collections = &CollectionsService{client: c}
+		c.Gear = &GearService{client: c}
+		c.Events = &EventsService{client: c}
 		if c.baseURL == "" {
 			c.baseURL = _baseURL
 		}
@@ -55,28 +121,278 @@ func WithBaseURL(baseURL string) Option {
 }
 
 func (c *Client) newAPIRequest(ctx context.Context, uri string, params map[string]string) (*http.Request, error) {
-	u, err := url.Parse(fmt.Sprintf("%s/%s", c.baseURL, uri))
+	body := make(map[string]any, len(params))
+	for k, v := range params {
+		body[k] = v
+	}
+	return c.newAPIRequestWithMethod(ctx, http.MethodGet, uri, body)
+}
+
+// newAPIRequestWithMethod creates a request for the api
+//
+// For the legacy api the authentication parameters are included in the json body. For the
+// v3 api the bearer token is set in the header and the parameters of GET requests are
+// encoded in the query.
+func (c *Client) newAPIRequestWithMethod(
+	ctx context.Context, method, uri string, params map[string]any) (*http.Request, error) {
+	u, err := url.Parse(c.endpoint(uri))
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	switch {
+	case !c.v3:
+		x := map[string]any{
+			"version":    apiVersion,
+			"apikey":     c.config.ClientID,
+			"auth_token": c.token.AccessToken,
+		}
+		for k, v := range params {
+			x[k] = v
+		}
+		b, err := json.Marshal(x)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	case method == http.MethodGet:
+		q := u.Query()
+		for k, v := range params {
+			q.Set(k, fmt.Sprintf("%v", v))
+		}
+		u.RawQuery = q.Encode()
+	case params != nil:
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	x := map[string]string{
+	c.authorize(req)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// endpoint returns the url for the uri of the api version in use
+func (c *Client) endpoint(uri string) string {
+	if c.v3 {
+		return fmt.Sprintf("%s/%s/%s", c.baseURL, apiV3Prefix, uri)
+	}
+	return fmt.Sprintf("%s/%s", c.baseURL, uri)
+}
+
+// authorize sets the headers of the request, including the bearer token for the v3 api
+func (c *Client) authorize(req *http.Request) {
+	req.Header.Set("User-Agent", activity.UserAgent)
+	if c.v3 {
+		c.token.SetAuthHeader(req)
+	}
+}
+
+// credentials returns the authentication parameters of the legacy api
+func (c *Client) credentials() map[string]string {
+	return map[string]string{
 		"version":    apiVersion,
 		"apikey":     c.config.ClientID,
 		"auth_token": c.token.AccessToken,
 	}
-	for k, v := range params {
-		x[k] = v
+}
+
+// unsupported returns an error if the v3 api is in use
+func (c *Client) unsupported(name string) error {
+	if c.v3 {
+		return fmt.Errorf("%s: %w by the v3 api", name, errors.ErrUnsupported)
+	}
+	return nil
+}
+
+// authenticated returns an error if the user is not the authenticated user of the v3 api
+//
+// A zero user id is the authenticated user.
+func (c *Client) authenticated(ctx context.Context, userID UserID) error {
+	if !c.v3 || userID == 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.me == 0 {
+		user, err := c.Users.AuthenticatedUser(ctx)
+		if err != nil {
+			return err
+		}
+		c.me = user.ID
+	}
+	if c.me != userID {
+		return fmt.Errorf("%w: user %d is not the authenticated user of the v3 api", activity.ErrInvalidRequest, userID)
 	}
-	b, err := json.Marshal(x)
+	return nil
+}
+
+// resource is a paginated list of entities in each api version
+type resource struct {
+	// user owning the entities in the legacy resource, the v3 resource is of the authenticated user
+	user UserID
+	// uri of the legacy resource
+	uri string
+	// uri of the v3 resource or empty if it is not served by the v3 api
+	v3 string
+	// key of the entities in the v3 response
+	key string
+}
+
+// pagePaginator queries pages of entities from a v3 resource supporting `page`
+//
+// The response contains the entities under the key of the resource and the pagination
+// in `meta` whose `next_page_url` is empty on the last page. The page size is chosen by
+// the server so `spec.Count` is not sent.
+type pagePaginator[T any] struct {
+	resource resource
+	client   *Client
+	entities []T
+	done     bool
+}
+
+func (p *pagePaginator[T]) PageSize() int {
+	return pageSize
+}
+
+func (p *pagePaginator[T]) Count() int {
+	return len(p.entities)
+}
+
+func (p *pagePaginator[T]) results() []T {
+	return p.entities
+}
+
+func (p *pagePaginator[T]) Do(ctx context.Context, spec activity.Pagination) (int, error) {
+	if p.done {
+		return 0, nil
+	}
+	params := map[string]any{"page": spec.Start}
+	req, err := p.client.newAPIRequestWithMethod(ctx, http.MethodGet, p.resource.v3, params)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	var res map[string]json.RawMessage
+	if err = p.client.do(req, &res); err != nil {
+		return 0, err
+	}
+	var results []T
+	if b, ok := res[p.resource.key]; ok {
+		if err = json.Unmarshal(b, &results); err != nil {
+			return 0, err
+		}
+	}
+	var meta struct {
+		Pagination struct {
+			RecordCount int    `json:"record_count"`
+			PageCount   int    `json:"page_count"`
+			NextPageURL string `json:"next_page_url"`
+		} `json:"pagination"`
+	}
+	if b, ok := res["meta"]; ok {
+		if err = json.Unmarshal(b, &meta); err != nil {
+			return 0, err
+		}
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), bytes.NewReader(b))
+	p.done = meta.Pagination.NextPageURL == ""
+	if spec.Total > 0 && len(p.entities)+len(results) > spec.Total {
+		results = results[:spec.Total-len(p.entities)]
+	}
+	p.entities = append(p.entities, results...)
+	return len(results), nil
+}
+
+// offsetPaginator queries pages of entities from a legacy endpoint supporting `offset` and `limit`
+//
+// The response contains the entities in `results`.
+type offsetPaginator[T any] struct {
+	resource resource
+	client   *Client
+	entities []T
+}
+
+func (p *offsetPaginator[T]) PageSize() int {
+	return pageSize
+}
+
+func (p *offsetPaginator[T]) Count() int {
+	return len(p.entities)
+}
+
+func (p *offsetPaginator[T]) results() []T {
+	return p.entities
+}
+
+func (p *offsetPaginator[T]) Do(ctx context.Context, spec activity.Pagination) (int, error) {
+	params := map[string]string{
+		// pagination uses the concept of page (based on strava), rwgps uses an offset by row
+		//  since pagination starts with page 1 (again, strava), subtract one from `start`
+		"offset": strconv.FormatInt(int64((spec.Start-1)*spec.Count), 10),
+		"limit":  strconv.FormatInt(int64(spec.Count), 10),
+	}
+	req, err := p.client.newAPIRequest(ctx, p.resource.uri, params)
 	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		Results      []T `json:"results"`
+		ResultsCount int `json:"results_count"`
+	}
+	if err = p.client.do(req, &res); err != nil {
+		return 0, err
+	}
+	if spec.Total > 0 && len(p.entities)+len(res.Results) > spec.Total {
+		res.Results = res.Results[:spec.Total-len(p.entities)]
+	}
+	p.entities = append(p.entities, res.Results...)
+	return len(res.Results), nil
+}
+
+// paginate returns the entities of an endpoint using the pagination of the api version in use
+func paginate[T any](ctx context.Context, client *Client, res resource, spec activity.Pagination) ([]T, error) {
+	var p interface {
+		activity.Paginator
+		results() []T
+	}
+	switch {
+	case !client.v3:
+		p = &offsetPaginator[T]{resource: res, client: client, entities: make([]T, 0)}
+	case res.v3 == "":
+		return nil, client.unsupported(res.uri)
+	default:
+		if err := client.authenticated(ctx, res.user); err != nil {
+			return nil, err
+		}
+		p = &pagePaginator[T]{resource: res, client: client, entities: make([]T, 0)}
+	}
+	if err := activity.Paginate(ctx, p, spec); err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", activity.UserAgent)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	return req, nil
+	return p.results(), nil
+}
+
+// do executes the http request and populates v with the result
+//
+// A response with an error status is returned as a *Fault.
+func (c *Client) do(req *http.Request, v any) error {
+	return activity.Do(c.client, req, v, newFault)
+}
+
+// newFault returns a Fault from the error response
+func newFault(res *http.Response) *Fault {
+	fault := &Fault{}
+	fault.FaultStatus = activity.DecodeFault(res, fault)
+	if fault.Code == 0 {
+		fault.Code = res.StatusCode
+	}
+	if fault.Message == "" {
+		fault.Message = http.StatusText(res.StatusCode)
+	}
+	return fault
 }
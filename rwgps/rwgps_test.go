@@ -8,17 +8,18 @@ import (
 	"github.com/bzimmer/activity/rwgps"
 )
 
-func newClient(before func(*http.ServeMux)) (*rwgps.Client, *httptest.Server) {
+func newClient(before func(*http.ServeMux), opts ...rwgps.Option) (*rwgps.Client, *httptest.Server) {
 	mux := http.NewServeMux()
 	if before != nil {
 		before(mux)
 	}
 	svr := httptest.NewServer(mux)
-	client, err := rwgps.NewClient(rwgps.WithBaseURL(svr.URL),
+	client, err := rwgps.NewClient(append([]rwgps.Option{
+		rwgps.WithBaseURL(svr.URL),
 		rwgps.WithHTTPTracing(false),
 		rwgps.WithClientCredentials("fooKey", ""),
 		rwgps.WithTokenCredentials("barToken", "", time.Time{}),
-	)
+	}, opts...)...)
 	if err != nil {
 		panic(err)
 	}
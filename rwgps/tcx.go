@@ -0,0 +1,181 @@
+package rwgps
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	tcxNamespace = "http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+	// tcxCourseNameLength is the maximum length of a course name
+	tcxCourseNameLength = 15
+	// tcxPointNameLength is the maximum length of a course point name
+	tcxPointNameLength = 10
+	// tcxSpeed is the speed (m/s) used to estimate times for routes without them
+	tcxSpeed = 20.0 * 1000 / 3600
+)
+
+type tcxDatabase struct {
+	XMLName xml.Name   `xml:"TrainingCenterDatabase"`
+	XMLNS   string     `xml:"xmlns,attr"`
+	Courses tcxCourses `xml:"Courses"`
+}
+
+type tcxCourses struct {
+	Course []*tcxCourse `xml:"Course"`
+}
+
+type tcxCourse struct {
+	Name         string            `xml:"Name"`
+	Lap          *tcxLap           `xml:"Lap"`
+	Track        *tcxTrack         `xml:"Track"`
+	Notes        string            `xml:"Notes,omitempty"`
+	CoursePoints []*tcxCoursePoint `xml:"CoursePoint"`
+}
+
+type tcxLap struct {
+	TotalTimeSeconds float64      `xml:"TotalTimeSeconds"`
+	DistanceMeters   float64      `xml:"DistanceMeters"`
+	BeginPosition    *tcxPosition `xml:"BeginPosition"`
+	EndPosition      *tcxPosition `xml:"EndPosition"`
+	Intensity        string       `xml:"Intensity"`
+}
+
+type tcxTrack struct {
+	Trackpoints []*tcxTrackpoint `xml:"Trackpoint"`
+}
+
+type tcxTrackpoint struct {
+	Time           time.Time    `xml:"Time"`
+	Position       *tcxPosition `xml:"Position"`
+	AltitudeMeters float64      `xml:"AltitudeMeters"`
+	DistanceMeters float64      `xml:"DistanceMeters"`
+	HeartRateBpm   *tcxValue    `xml:"HeartRateBpm,omitempty"`
+	Cadence        int          `xml:"Cadence,omitempty"`
+}
+
+type tcxValue struct {
+	Value int `xml:"Value"`
+}
+
+type tcxPosition struct {
+	LatitudeDegrees  float64 `xml:"LatitudeDegrees"`
+	LongitudeDegrees float64 `xml:"LongitudeDegrees"`
+}
+
+type tcxCoursePoint struct {
+	Name      string       `xml:"Name"`
+	Time      time.Time    `xml:"Time"`
+	Position  *tcxPosition `xml:"Position"`
+	PointType string       `xml:"PointType"`
+	Notes     string       `xml:"Notes,omitempty"`
+}
+
+// TCX encodes the trip as a TCX course including the course points as CoursePoints
+//
+// TCX requires a time for every point so for routes, which have no times, the times are
+// estimated from the distance at a constant speed starting at the time the route was created.
+func (t *Trip) TCX() ([]byte, error) {
+	if len(t.TrackPoints) == 0 {
+		return nil, errors.New("no track points")
+	}
+	name := t.Name
+	if name == "" {
+		name = strconv.FormatInt(t.ID, 10)
+	}
+	start := t.DepartedAt
+	if start.IsZero() {
+		start = t.CreatedAt
+	}
+	times := make([]time.Time, len(t.TrackPoints))
+	track := &tcxTrack{Trackpoints: make([]*tcxTrackpoint, len(t.TrackPoints))}
+	for i, tp := range t.TrackPoints {
+		times[i] = t.pointTime(start, tp)
+		track.Trackpoints[i] = &tcxTrackpoint{
+			Time:           times[i],
+			Position:       &tcxPosition{LatitudeDegrees: tp.Latitude, LongitudeDegrees: tp.Longitude},
+			AltitudeMeters: tp.Elevation.Meters(),
+			DistanceMeters: tp.Distance.Meters(),
+			Cadence:        int(math.Round(tp.Cadence)),
+		}
+		if tp.HeartRate > 0 {
+			track.Trackpoints[i].HeartRateBpm = &tcxValue{Value: int(math.Round(tp.HeartRate))}
+		}
+	}
+	first, last := track.Trackpoints[0], track.Trackpoints[len(track.Trackpoints)-1]
+	course := &tcxCourse{
+		Name: truncate(name, tcxCourseNameLength),
+		Lap: &tcxLap{
+			TotalTimeSeconds: last.Time.Sub(first.Time).Seconds(),
+			DistanceMeters:   last.DistanceMeters,
+			BeginPosition:    first.Position,
+			EndPosition:      last.Position,
+			Intensity:        "Active",
+		},
+		Track: track,
+		Notes: t.Description,
+	}
+	for _, cp := range t.CoursePoints {
+		var tm time.Time
+		if cp.Index >= 0 && cp.Index < len(times) {
+			tm = times[cp.Index]
+		} else {
+			tm = start.Add(time.Duration(cp.Distance.Meters() / tcxSpeed * float64(time.Second)))
+		}
+		course.CoursePoints = append(course.CoursePoints, &tcxCoursePoint{
+			Name:      truncate(cp.Notes, tcxPointNameLength),
+			Time:      tm,
+			Position:  &tcxPosition{LatitudeDegrees: cp.Latitude, LongitudeDegrees: cp.Longitude},
+			PointType: pointType(cp.Type),
+			Notes:     cp.Notes,
+		})
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	db := &tcxDatabase{XMLNS: tcxNamespace, Courses: tcxCourses{Course: []*tcxCourse{course}}}
+	if err := enc.Encode(db); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (t *Trip) pointTime(start time.Time, tp *TrackPoint) time.Time {
+	if t.Type == TypeTrip.String() && tp.Time > 0 {
+		return time.Unix(int64(tp.Time), 0).UTC()
+	}
+	return start.Add(time.Duration(tp.Distance.Meters() / tcxSpeed * float64(time.Second))).UTC()
+}
+
+// pointType maps a RWGPS cue type to a TCX CoursePoint PointType
+func pointType(typ string) string {
+	switch t := strings.ToLower(typ); {
+	case strings.Contains(t, "left"):
+		return "Left"
+	case strings.Contains(t, "right"):
+		return "Right"
+	case strings.Contains(t, "straight"):
+		return "Straight"
+	case t == "summit", t == "valley", t == "water", t == "food", t == "danger", t == "sprint":
+		return strings.ToUpper(t[:1]) + t[1:]
+	case t == "first aid":
+		return "First Aid"
+	default:
+		return "Generic"
+	}
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
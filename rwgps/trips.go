@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/bzimmer/activity"
 )
@@ -19,67 +20,19 @@ const pageSize = 100
 // TripsService provides access to Trips and Routes via the RWGPS API
 type TripsService service
 
-type tripsPaginator struct {
-	service TripsService
-	userID  UserID
-	trips   []*Trip
-	kind    string
-}
-
-func (p *tripsPaginator) PageSize() int {
-	return pageSize
-}
-
-func (p *tripsPaginator) Count() int {
-	return len(p.trips)
-}
-
-func (p *tripsPaginator) Do(ctx context.Context, spec activity.Pagination) (int, error) {
-	uri := fmt.Sprintf("users/%d/%s.json", p.userID, p.kind)
-	params := map[string]string{
-		// pagination uses the concept of page (based on strava), rwgps uses an offset by row
-		//  since pagination starts with page 1 (again, strava), subtract one from `start`
-		"offset": strconv.FormatInt(int64((spec.Start-1)*p.PageSize()), 10),
-		"limit":  strconv.FormatInt(int64(spec.Count), 10),
-	}
-	req, err := p.service.client.newAPIRequest(ctx, uri, params)
-	if err != nil {
-		return 0, err
-	}
-	type TripsResponse struct {
-		Results      []*Trip `json:"results"`
-		ResultsCount int     `json:"results_count"`
-	}
-	res := &TripsResponse{}
-	err = p.service.client.do(req, res)
-	if err != nil {
-		return 0, err
-	}
-	if spec.Total > 0 && len(p.trips)+len(res.Results) > spec.Total {
-		res.Results = res.Results[:spec.Total-len(p.trips)]
-	}
-	p.trips = append(p.trips, res.Results...)
-	return len(res.Results), nil
-}
-
 // Trips returns a slice of trips
 func (s *TripsService) Trips(ctx context.Context, userID UserID, spec activity.Pagination) ([]*Trip, error) {
-	p := &tripsPaginator{service: *s, userID: userID, kind: "trips", trips: make([]*Trip, 0)}
-	err := activity.Paginate(ctx, p, spec)
-	if err != nil {
-		return nil, err
-	}
-	return p.trips, nil
+	return s.trips(ctx, userID, "trips", spec)
 }
 
 // Routes returns a slice of routes
 func (s *TripsService) Routes(ctx context.Context, userID UserID, spec activity.Pagination) ([]*Trip, error) {
-	p := &tripsPaginator{service: *s, userID: userID, kind: "routes", trips: make([]*Trip, 0)}
-	err := activity.Paginate(ctx, p, spec)
-	if err != nil {
-		return nil, err
-	}
-	return p.trips, nil
+	return s.trips(ctx, userID, "routes", spec)
+}
+
+func (s *TripsService) trips(ctx context.Context, userID UserID, kind string, spec activity.Pagination) ([]*Trip, error) {
+	res := resource{user: userID, uri: fmt.Sprintf("users/%d/%s.json", userID, kind), v3: kind + ".json", key: kind}
+	return paginate[*Trip](ctx, s.client, res, spec)
 }
 
 // Trip returns a trip for the `tripID`
@@ -97,7 +50,10 @@ func (s *TripsService) trip(ctx context.Context, entity Type, uri string) (*Trip
 	if err != nil {
 		return nil, err
 	}
+	return s.doTrip(req, entity)
+}
 
+func (s *TripsService) doTrip(req *http.Request, entity Type) (*Trip, error) {
 	type TripResponse struct {
 		Type  string `json:"type"`
 		Trip  *Trip  `json:"trip"`
@@ -105,8 +61,7 @@ func (s *TripsService) trip(ctx context.Context, entity Type, uri string) (*Trip
 	}
 
 	res := &TripResponse{}
-	err = s.client.do(req, res)
-	if err != nil {
+	if err := s.client.do(req, res); err != nil {
 		return nil, err
 	}
 
@@ -117,27 +72,76 @@ func (s *TripsService) trip(ctx context.Context, entity Type, uri string) (*Trip
 	case TypeRoute:
 		t = res.Route
 	}
+	if t == nil {
+		return nil, fmt.Errorf("missing %s in response", entity)
+	}
 	t.Type = entity.String()
 	return t, nil
 }
 
+// UpdateTrip updates the trip returning the updated trip
+func (s *TripsService) UpdateTrip(ctx context.Context, trip *UpdatableTrip) (*Trip, error) {
+	return s.update(ctx, TypeTrip, fmt.Sprintf("trips/%d.json", trip.ID), trip)
+}
+
+// UpdateRoute updates the route returning the updated route
+func (s *TripsService) UpdateRoute(ctx context.Context, route *UpdatableTrip) (*Trip, error) {
+	return s.update(ctx, TypeRoute, fmt.Sprintf("routes/%d.json", route.ID), route)
+}
+
+func (s *TripsService) update(ctx context.Context, entity Type, uri string, trip *UpdatableTrip) (*Trip, error) {
+	req, err := s.client.newAPIRequestWithMethod(ctx, http.MethodPut, uri, map[string]any{entity.String(): trip})
+	if err != nil {
+		return nil, err
+	}
+	return s.doTrip(req, entity)
+}
+
+// DeleteTrip deletes the trip
+func (s *TripsService) DeleteTrip(ctx context.Context, tripID int64) error {
+	return s.delete(ctx, fmt.Sprintf("trips/%d.json", tripID))
+}
+
+// DeleteRoute deletes the route
+func (s *TripsService) DeleteRoute(ctx context.Context, routeID int64) error {
+	return s.delete(ctx, fmt.Sprintf("routes/%d.json", routeID))
+}
+
+func (s *TripsService) delete(ctx context.Context, uri string) error {
+	req, err := s.client.newAPIRequestWithMethod(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return err
+	}
+	return s.client.do(req, nil)
+}
+
 // Upload the file for the user
-func (s *TripsService) Upload(ctx context.Context, file *activity.File) (*Upload, error) {
+func (s *TripsService) Upload(ctx context.Context, file *activity.File, opts ...UploadOption) (*Upload, error) {
 	if file == nil || file.Name == "" || file.Format == activity.FormatOriginal {
 		return nil, errors.New("missing upload file, name, or format")
 	}
+	if err := s.client.unsupported("upload"); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{
+		"trip[name]":        "",
+		"trip[description]": "",
+	}
+	for _, opt := range opts {
+		if err := opt(fields); err != nil {
+			return nil, err
+		}
+	}
+	fields["filename"] = file.Name
+	fields["trip[bad_elevations]"] = "false"
+	for k, v := range s.client.credentials() {
+		fields[k] = v
+	}
 
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
-	for k, v := range map[string]string{
-		"filename":             file.Name,
-		"trip[name]":           "",
-		"trip[description]":    "",
-		"trip[bad_elevations]": "false",
-		"version":              apiVersion,
-		"apikey":               s.client.config.ClientID,
-		"auth_token":           s.client.token.AccessToken,
-	} {
+	for k, v := range fields {
 		if err := w.WriteField(k, v); err != nil {
 			return nil, err
 		}
@@ -153,11 +157,11 @@ func (s *TripsService) Upload(ctx context.Context, file *activity.File) (*Upload
 		return nil, err
 	}
 
-	uri := fmt.Sprintf("%s/trips.json", s.client.baseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, &b)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.endpoint("trips.json"), &b)
 	if err != nil {
 		return nil, err
 	}
+	s.client.authorize(req)
 	req.Header.Set("Content-Type", w.FormDataContentType())
 
 	res := &Upload{}
@@ -170,11 +174,40 @@ func (s *TripsService) Upload(ctx context.Context, file *activity.File) (*Upload
 
 // Status returns the status of an upload request
 func (s *TripsService) Status(ctx context.Context, uploadID int64) (*Upload, error) {
+	return s.status(ctx, []int64{uploadID}, false)
+}
+
+// BatchStatus returns the status of the queued tasks for many upload requests in one request
+//
+// If `includeObjects` is true the trip created by each successful task is included.
+func (s *TripsService) BatchStatus(ctx context.Context, includeObjects bool, uploadIDs ...int64) ([]*Task, error) {
+	if len(uploadIDs) == 0 {
+		return nil, errors.New("missing upload ids")
+	}
+	res, err := s.status(ctx, uploadIDs, includeObjects)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range res.Tasks {
+		if task.Trip != nil {
+			task.Trip.Type = TypeTrip.String()
+		}
+	}
+	return res.Tasks, nil
+}
+
+func (s *TripsService) status(ctx context.Context, uploadIDs []int64, includeObjects bool) (*Upload, error) {
+	if err := s.client.unsupported("upload status"); err != nil {
+		return nil, err
+	}
 	uri := "queued_tasks/status.json"
-	id := strconv.FormatInt(uploadID, 10)
+	ids := make([]string, len(uploadIDs))
+	for i := range uploadIDs {
+		ids[i] = strconv.FormatInt(uploadIDs[i], 10)
+	}
 	req, err := s.client.newAPIRequest(ctx, uri, map[string]string{
-		"ids":             id,
-		"include_objects": "false",
+		"ids":             strings.Join(ids, ","),
+		"include_objects": strconv.FormatBool(includeObjects),
 	})
 	if err != nil {
 		return nil, err
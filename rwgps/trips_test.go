@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -174,6 +176,59 @@ func TestPagination(t *testing.T) {
 	}
 }
 
+func TestPaginationCount(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	// five trips served by offset and limit
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Offset string `json:"offset"`
+			Limit  string `json:"limit"`
+		}
+		a.NoError(json.NewDecoder(r.Body).Decode(&body))
+		offset, err := strconv.Atoi(body.Offset)
+		a.NoError(err)
+		limit, err := strconv.Atoi(body.Limit)
+		a.NoError(err)
+		var trips []*rwgps.Trip
+		for id := offset + 1; id <= 5 && id <= offset+limit; id++ {
+			trips = append(trips, &rwgps.Trip{ID: int64(id)})
+		}
+		a.NoError(json.NewEncoder(w).Encode(map[string]any{"results": trips}))
+	}
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/users/88272/trips.json", handler)
+		mux.HandleFunc("/users/88272/routes.json", handler)
+	})
+	defer svr.Close()
+
+	tests := []struct {
+		name string
+		spec activity.Pagination
+		ids  []int64
+	}{
+		{name: "count", spec: activity.Pagination{Count: 2}, ids: []int64{1, 2, 3, 4, 5}},
+		{name: "count and start", spec: activity.Pagination{Start: 2, Count: 2}, ids: []int64{3, 4, 5}},
+		{name: "count and total", spec: activity.Pagination{Count: 2, Total: 3}, ids: []int64{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, f := range []func(context.Context, rwgps.UserID, activity.Pagination) ([]*rwgps.Trip, error){
+				client.Trips.Trips, client.Trips.Routes,
+			} {
+				trips, err := f(context.TODO(), rwgps.UserID(88272), tt.spec)
+				a.NoError(err)
+				ids := make([]int64, len(trips))
+				for i := range trips {
+					ids[i] = trips[i].ID
+				}
+				a.Equal(tt.ids, ids)
+			}
+		})
+	}
+}
+
 func TestStatus(t *testing.T) {
 	t.Parallel()
 	a := assert.New(t)
@@ -219,3 +274,126 @@ func TestStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	name, visibility := "Copied Title", 1
+	tests := []struct {
+		name  string
+		route bool
+		path  string
+	}{
+		{name: "update trip", path: "/trips/94.json"},
+		{name: "update route", route: true, path: "/routes/141014.json"},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClient(func(mux *http.ServeMux) {
+				mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+					a.Equal(http.MethodPut, r.Method)
+					body := make(map[string]json.RawMessage)
+					a.NoError(json.NewDecoder(r.Body).Decode(&body))
+					a.JSONEq(`"barToken"`, string(body["auth_token"]))
+					a.JSONEq(`"fooKey"`, string(body["apikey"]))
+					key := "trip"
+					if tt.route {
+						key = "route"
+					}
+					a.JSONEq(`{"name":"Copied Title","visibility":1}`, string(body[key]))
+					enc := json.NewEncoder(w)
+					a.NoError(enc.Encode(map[string]any{key: &rwgps.Trip{ID: 1, Name: name}}))
+				})
+			})
+			defer svr.Close()
+			var (
+				trip *rwgps.Trip
+				err  error
+			)
+			if tt.route {
+				trip, err = client.Trips.UpdateRoute(context.TODO(),
+					&rwgps.UpdatableTrip{ID: 141014, Name: &name, Visibility: &visibility})
+			} else {
+				trip, err = client.Trips.UpdateTrip(context.TODO(),
+					&rwgps.UpdatableTrip{ID: 94, Name: &name, Visibility: &visibility})
+			}
+			a.NoError(err)
+			a.Equal(name, trip.Name)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/trips/94.json", func(w http.ResponseWriter, r *http.Request) {
+			a.Equal(http.MethodDelete, r.Method)
+			body := make(map[string]string)
+			a.NoError(json.NewDecoder(r.Body).Decode(&body))
+			a.Equal("barToken", body["auth_token"])
+			w.WriteHeader(http.StatusNoContent)
+		})
+		mux.HandleFunc("/routes/141014.json", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	})
+	defer svr.Close()
+	a.NoError(client.Trips.DeleteTrip(context.TODO(), 94))
+	a.ErrorIs(client.Trips.DeleteRoute(context.TODO(), 141014), activity.ErrNotFound)
+}
+
+func TestBatchStatus(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/queued_tasks/status.json", func(w http.ResponseWriter, r *http.Request) {
+			m := make(map[string]string)
+			a.NoError(json.NewDecoder(r.Body).Decode(&m))
+			a.Equal("true", m["include_objects"])
+			var tasks []*rwgps.Task
+			for _, id := range strings.Split(m["ids"], ",") {
+				n, err := strconv.Atoi(id)
+				a.NoError(err)
+				task := &rwgps.Task{ID: n}
+				switch n {
+				case 1:
+					task.Status, task.Trip = 1, &rwgps.Trip{ID: 94}
+				case 2:
+					task.Status, task.Message = -1, "duplicate of trip 88"
+				}
+				tasks = append(tasks, task)
+			}
+			a.NoError(json.NewEncoder(w).Encode(&rwgps.Upload{Tasks: tasks}))
+		})
+	})
+	defer svr.Close()
+
+	ctx := context.Background()
+	tasks, err := client.Trips.BatchStatus(ctx, true, 1, 2, 3)
+	a.NoError(err)
+	a.Len(tasks, 3)
+	a.Equal(rwgps.TypeTrip.String(), tasks[0].Trip.Type)
+	a.Equal(&activity.UploadOutcome{Status: activity.UploadCreated, ActivityID: 94}, tasks[0].Outcome())
+	a.Equal(activity.UploadDuplicate, tasks[1].Outcome().Status)
+	a.False(tasks[2].Done())
+
+	tasks, err = client.Trips.BatchStatus(ctx, true)
+	a.Error(err)
+	a.Nil(tasks)
+
+	polls := make(map[activity.UploadID]*activity.Poll)
+	p := activity.NewMultiPoller(client.Uploader(), activity.WithInterval(time.Millisecond), activity.WithIterations(2))
+	for poll := range p.PollAll(ctx, 1, 2, 3) {
+		polls[poll.ID] = poll
+	}
+	a.Len(polls, 3)
+	a.Equal(int64(94), activity.Outcome(polls[1].Upload).ActivityID)
+	a.Equal(activity.UploadDuplicate, activity.Outcome(polls[2].Upload).Status)
+	a.ErrorIs(polls[3].Err, activity.ErrExceededIterations)
+}
@@ -2,24 +2,79 @@ package rwgps
 
 import (
 	"context"
+	"errors"
 
 	"github.com/bzimmer/activity"
 )
 
+// UploadOption sets metadata of the trip created by an upload
+type UploadOption func(map[string]string) error
+
+// WithUploadName sets the name of the trip
+func WithUploadName(name string) UploadOption {
+	return func(fields map[string]string) error {
+		fields["trip[name]"] = name
+		return nil
+	}
+}
+
+// WithUploadDescription sets the description of the trip
+func WithUploadDescription(description string) UploadOption {
+	return func(fields map[string]string) error {
+		fields["trip[description]"] = description
+		return nil
+	}
+}
+
+// uploadOptions returns the options for the file's metadata
+func uploadOptions(md *activity.UploadMetadata) []UploadOption {
+	if md == nil {
+		return nil
+	}
+	var opts []UploadOption
+	if md.Name != "" {
+		opts = append(opts, WithUploadName(md.Name))
+	}
+	if md.Description != "" {
+		opts = append(opts, WithUploadDescription(md.Description))
+	}
+	return opts
+}
+
 type uploader struct {
 	s *TripsService
 }
 
-func newUploader(s *TripsService) activity.Uploader {
+func newUploader(s *TripsService) activity.BatchUploader {
 	return &uploader{s: s}
 }
 
-// Upload uploads a file
+// Upload uploads the file setting the trip's name and description from the file's metadata
 func (u *uploader) Upload(ctx context.Context, file *activity.File) (activity.Upload, error) {
-	return u.s.Upload(ctx, file)
+	if file == nil {
+		return nil, errors.New("missing upload file")
+	}
+	return u.s.Upload(ctx, file, uploadOptions(file.Metadata)...)
 }
 
 // Status returns the processing status of a file
 func (u *uploader) Status(ctx context.Context, id activity.UploadID) (activity.Upload, error) {
 	return u.s.Status(ctx, int64(id))
 }
+
+// Statuses returns the processing status of the files in one request
+func (u *uploader) Statuses(ctx context.Context, ids ...activity.UploadID) ([]activity.Upload, error) {
+	uploadIDs := make([]int64, len(ids))
+	for i := range ids {
+		uploadIDs[i] = int64(ids[i])
+	}
+	tasks, err := u.s.BatchStatus(ctx, true, uploadIDs...)
+	if err != nil {
+		return nil, err
+	}
+	uploads := make([]activity.Upload, len(tasks))
+	for i := range tasks {
+		uploads[i] = tasks[i]
+	}
+	return uploads, nil
+}
@@ -1,9 +1,16 @@
 package rwgps_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+	"github.com/bzimmer/activity/rwgps"
 )
 
 func TestUploader(t *testing.T) {
@@ -14,4 +21,62 @@ func TestUploader(t *testing.T) {
 	a.NotNil(client)
 	uploader := client.Uploader()
 	a.NotNil(uploader)
+	_, ok := uploader.(activity.BatchUploader)
+	a.True(ok)
+}
+
+func TestUploadOptions(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name        string
+		opts        []rwgps.UploadOption
+		md          *activity.UploadMetadata
+		title       string
+		description string
+	}{
+		{name: "no options"},
+		{
+			name:        "name and description",
+			opts:        []rwgps.UploadOption{rwgps.WithUploadName("Morning Ride"), rwgps.WithUploadDescription("copied")},
+			title:       "Morning Ride",
+			description: "copied",
+		},
+		{
+			name:        "file metadata",
+			md:          &activity.UploadMetadata{Name: "Evening Ride", ExternalID: "ignored"},
+			title:       "Evening Ride",
+			description: "",
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClient(func(mux *http.ServeMux) {
+				mux.HandleFunc("/trips.json", func(w http.ResponseWriter, r *http.Request) {
+					a.NoError(r.ParseMultipartForm(1024 * 1024))
+					a.Equal(tt.title, r.FormValue("trip[name]"))
+					a.Equal(tt.description, r.FormValue("trip[description]"))
+					a.Equal("barToken", r.FormValue("auth_token"))
+					a.Equal("ride.gpx", r.FormValue("filename"))
+					a.NoError(json.NewEncoder(w).Encode(&rwgps.Upload{TaskID: 7818, Success: 1}))
+				})
+			})
+			defer svr.Close()
+			file := &activity.File{
+				Name: "ride.gpx", Format: activity.FormatGPX, Reader: strings.NewReader("<gpx/>"), Metadata: tt.md}
+			var upload activity.Upload
+			var err error
+			switch {
+			case tt.md != nil:
+				upload, err = client.Uploader().Upload(context.TODO(), file)
+			default:
+				upload, err = client.Trips.Upload(context.TODO(), file, tt.opts...)
+			}
+			a.NoError(err)
+			a.Equal(activity.UploadID(7818), upload.Identifier())
+		})
+	}
 }
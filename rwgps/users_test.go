@@ -2,11 +2,14 @@ package rwgps_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bzimmer/activity"
 	"github.com/bzimmer/activity/rwgps"
 )
 
@@ -56,3 +59,37 @@ func TestAthlete(t *testing.T) {
 		})
 	}
 }
+
+func TestFaultErrors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name   string
+		status int
+		err    error
+	}{
+		{name: "not found", status: http.StatusNotFound, err: activity.ErrNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized, err: activity.ErrUnauthorized},
+		{name: "rate limited", status: http.StatusTooManyRequests, err: activity.ErrRateLimited},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClient(func(mux *http.ServeMux) {
+				mux.HandleFunc("/users/current.json", func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Retry-After", "5")
+					w.WriteHeader(tt.status)
+				})
+			})
+			defer svr.Close()
+			_, err := client.Users.AuthenticatedUser(context.Background())
+			a.ErrorIs(err, tt.err)
+			var fault *rwgps.Fault
+			a.True(errors.As(err, &fault))
+			a.Equal(tt.status, fault.HTTPStatus())
+			a.Equal(5*time.Second, fault.RetryAfter())
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package rwgps_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+	"github.com/bzimmer/activity/rwgps"
+)
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	endpoint := rwgps.Endpoint()
+	a.NotEmpty(endpoint.AuthURL)
+	a.NotEmpty(endpoint.TokenURL)
+}
+
+func TestV3(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	bearer := func(r *http.Request) {
+		a.Equal("Bearer barToken", r.Header.Get("Authorization"))
+		a.Empty(r.URL.Query().Get("apikey"))
+		a.Empty(r.URL.Query().Get("auth_token"))
+	}
+
+	var current atomic.Int32
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/api/v3/users/current.json", func(w http.ResponseWriter, r *http.Request) {
+			bearer(r)
+			current.Add(1)
+			b, err := io.ReadAll(r.Body)
+			a.NoError(err)
+			a.Empty(b)
+			http.ServeFile(w, r, "testdata/rwgps_v3_user_current.json")
+		})
+		mux.HandleFunc("/api/v3/trips.json", func(w http.ResponseWriter, r *http.Request) {
+			bearer(r)
+			a.Equal(http.MethodGet, r.Method)
+			a.Empty(r.URL.Query().Get("offset"))
+			page := r.URL.Query().Get("page")
+			a.Contains([]string{"1", "2"}, page)
+			http.ServeFile(w, r, fmt.Sprintf("testdata/rwgps_v3_trips_%s.json", page))
+		})
+		mux.HandleFunc("/api/v3/routes.json", func(w http.ResponseWriter, r *http.Request) {
+			bearer(r)
+			a.Equal("1", r.URL.Query().Get("page"))
+			http.ServeFile(w, r, "testdata/rwgps_v3_routes_1.json")
+		})
+		mux.HandleFunc("/api/v3/trips/94.json", func(w http.ResponseWriter, r *http.Request) {
+			bearer(r)
+			switch r.Method {
+			case http.MethodGet:
+				http.ServeFile(w, r, "testdata/rwgps_v3_trip_94.json")
+			case http.MethodPut:
+				body := make(map[string]json.RawMessage)
+				a.NoError(json.NewDecoder(r.Body).Decode(&body))
+				a.NotContains(body, "apikey")
+				a.JSONEq(`{"name":"Copied"}`, string(body["trip"]))
+				a.NoError(json.NewEncoder(w).Encode(map[string]any{"trip": &rwgps.Trip{ID: 94, Name: "Copied"}}))
+			}
+		})
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			a.Failf("unexpected request", "%s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		})
+	}, rwgps.WithAPIV3())
+	defer svr.Close()
+
+	ctx := context.Background()
+	user, err := client.Users.AuthenticatedUser(ctx)
+	a.NoError(err)
+	a.Equal(rwgps.UserID(1122), user.ID)
+	a.Equal("Mr Rwgps", user.Name)
+
+	for _, tt := range []struct {
+		user rwgps.UserID
+		spec activity.Pagination
+		ids  []int64
+	}{
+		{user: 1122, spec: activity.Pagination{}, ids: []int64{94, 95, 96}},
+		{user: 1122, spec: activity.Pagination{Total: 2}, ids: []int64{94, 95}},
+		{spec: activity.Pagination{Total: 1}, ids: []int64{94}},
+		{spec: activity.Pagination{Start: 2}, ids: []int64{96}},
+	} {
+		trips, err := client.Trips.Trips(ctx, tt.user, tt.spec)
+		a.NoError(err)
+		ids := make([]int64, len(trips))
+		for i := range trips {
+			ids[i] = trips[i].ID
+		}
+		a.Equal(tt.ids, ids, fmt.Sprintf("%#v", tt.spec))
+	}
+
+	routes, err := client.Trips.Routes(ctx, 1122, activity.Pagination{})
+	a.NoError(err)
+	a.Len(routes, 1)
+	a.Equal(int64(141014), routes[0].ID)
+
+	// the v3 api lists only the entities of the authenticated user
+	trips, err := client.Trips.Trips(ctx, 88, activity.Pagination{})
+	a.ErrorIs(err, activity.ErrInvalidRequest)
+	a.Nil(trips)
+	routes, err = client.Trips.Routes(ctx, 88, activity.Pagination{})
+	a.ErrorIs(err, activity.ErrInvalidRequest)
+	a.Nil(routes)
+	events, err := client.Events.Events(ctx, 88, activity.Pagination{})
+	a.ErrorIs(err, activity.ErrInvalidRequest)
+	a.Nil(events)
+	collections, err := client.Collections.Collections(ctx, 88, activity.Pagination{})
+	a.ErrorIs(err, activity.ErrInvalidRequest)
+	a.Nil(collections)
+	// the user ids are checked against the authenticated user queried once and cached
+	a.Equal(int32(2), current.Load())
+
+	trip, err := client.Trips.Trip(ctx, 94)
+	a.NoError(err)
+	a.Equal(rwgps.TypeTrip.String(), trip.Type)
+	a.Equal("Peak To Peak", trip.Name)
+	a.Len(trip.TrackPoints, 2)
+
+	name := "Copied"
+	trip, err = client.Trips.UpdateTrip(ctx, &rwgps.UpdatableTrip{ID: 94, Name: &name})
+	a.NoError(err)
+	a.Equal(name, trip.Name)
+}
+
+func TestV3Unsupported(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClient(func(mux *http.ServeMux) {
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			a.Failf("unexpected request", "%s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		})
+	}, rwgps.WithAPIV3())
+	defer svr.Close()
+
+	ctx := context.Background()
+	for name, f := range map[string]func() error{
+		"gear": func() error {
+			_, err := client.Gear.Gear(ctx, 1122, activity.Pagination{})
+			return err
+		},
+		"collection trips": func() error {
+			_, err := client.Collections.Trips(ctx, 7, activity.Pagination{})
+			return err
+		},
+		"upload": func() error {
+			file := &activity.File{Name: "ride.gpx", Format: activity.FormatGPX, Reader: strings.NewReader("<gpx/>")}
+			_, err := client.Uploader().Upload(ctx, file)
+			return err
+		},
+		"status": func() error {
+			_, err := client.Trips.Status(ctx, 7818)
+			return err
+		},
+		"export": func() error {
+			_, err := client.Exporter().Export(ctx, 94)
+			return err
+		},
+	} {
+		err := f()
+		a.Error(err, name)
+		a.True(errors.Is(err, errors.ErrUnsupported), name)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -97,13 +99,83 @@ func (p *channelPaginator) Do(ctx context.Context, spec activity.Pagination) (in
 	return len(acts), nil
 }
 
+// Resolution of the data streams
+type Resolution string
+
+const (
+	// ResolutionLow returns up to 100 points per stream
+	ResolutionLow Resolution = "low"
+	// ResolutionMedium returns up to 1000 points per stream
+	ResolutionMedium Resolution = "medium"
+	// ResolutionHigh returns up to 10000 points per stream
+	ResolutionHigh Resolution = "high"
+)
+
+// SeriesType is the base series used when downsampling streams
+type SeriesType string
+
+const (
+	// SeriesTypeTime downsamples streams by time
+	SeriesTypeTime SeriesType = "time"
+	// SeriesTypeDistance downsamples streams by distance
+	SeriesTypeDistance SeriesType = "distance"
+)
+
+// WithResolution sets the resolution of the requested streams
+func WithResolution(resolution Resolution) APIOption {
+	return func(v url.Values) error {
+		switch resolution {
+		case ResolutionLow, ResolutionMedium, ResolutionHigh:
+			v.Set("resolution", string(resolution))
+			return nil
+		default:
+			return fmt.Errorf("invalid resolution '%s'", resolution)
+		}
+	}
+}
+
+// WithSeriesType sets the series type used for downsampling the requested streams
+func WithSeriesType(seriesType SeriesType) APIOption {
+	return func(v url.Values) error {
+		switch seriesType {
+		case SeriesTypeTime, SeriesTypeDistance:
+			v.Set("series_type", string(seriesType))
+			return nil
+		default:
+			return fmt.Errorf("invalid series type '%s'", seriesType)
+		}
+	}
+}
+
 // Streams returns the activity's data streams
 func (s *ActivityService) Streams(ctx context.Context, activityID int64, streams ...string) (*Streams, error) {
+	return s.StreamsWithOptions(ctx, activityID, streams)
+}
+
+// AllStreams returns all the available data streams for the activity
+func (s *ActivityService) AllStreams(ctx context.Context, activityID int64, opts ...APIOption) (*Streams, error) {
+	return s.StreamsWithOptions(ctx, activityID, s.StreamSetNames(), opts...)
+}
+
+// StreamsWithOptions returns the activity's data streams using the options
+// (eg WithResolution, WithSeriesType) to control the query
+func (s *ActivityService) StreamsWithOptions(
+	ctx context.Context, activityID int64, streams []string, opts ...APIOption) (*Streams, error) {
 	if err := s.validateStreams(streams); err != nil {
 		return nil, err
 	}
+	v := make(url.Values)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(v); err != nil {
+			return nil, err
+		}
+	}
+	v.Set("key_by_type", "true")
 	keys := strings.Join(streams, ",")
-	uri := fmt.Sprintf("activities/%d/streams/%s?key_by_type=true", activityID, keys)
+	uri := fmt.Sprintf("activities/%d/streams/%s?%s", activityID, keys, v.Encode())
 	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, err
@@ -189,18 +261,31 @@ func ActivitiesIter(res <-chan *ActivityResult, iter ActivityIterFunc) error {
 // Upload the file for the user
 //
 // More information can be found at https://developers.strava.com/docs/uploads/
-func (s *ActivityService) Upload(ctx context.Context, file *activity.File) (*Upload, error) {
+func (s *ActivityService) Upload(ctx context.Context, file *activity.File, opts ...UploadOption) (*Upload, error) {
 	if file == nil || file.Name == "" || file.Format == activity.FormatOriginal {
 		return nil, errors.New("missing upload file, name, or format")
 	}
 
+	fields := url.Values{}
+	for _, opt := range opts {
+		if err := opt(fields); err != nil {
+			return nil, err
+		}
+	}
+	fields.Set("filename", file.Name)
+	fields.Set("data_type", file.Format.String())
+
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
-	if err := w.WriteField("filename", file.Name); err != nil {
-		return nil, err
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
 	}
-	if err := w.WriteField("data_type", file.Format.String()); err != nil {
-		return nil, err
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := w.WriteField(key, fields.Get(key)); err != nil {
+			return nil, err
+		}
 	}
 	fw, err := w.CreateFormFile("file", file.Name)
 	if err != nil {
@@ -265,6 +350,17 @@ func (s *ActivityService) StreamSets() map[string]string {
 	return streamsets()
 }
 
+// StreamSetNames returns the sorted names of all valid streams
+func (s *ActivityService) StreamSetNames() []string {
+	x := streamsets()
+	names := make([]string, 0, len(x))
+	for name := range x {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (s *ActivityService) validateStreams(streams []string) error {
 	x := streamsets()
 	for i := range streams {
@@ -291,9 +387,53 @@ func (s *ActivityService) Photos(ctx context.Context, activityID int64, size int
 	return photos, nil
 }
 
+// Laps returns the laps of an activity
+func (s *ActivityService) Laps(ctx context.Context, activityID int64) ([]*Lap, error) {
+	uri := fmt.Sprintf("activities/%d/laps", activityID)
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	var laps []*Lap
+	if err = s.client.do(req, &laps); err != nil {
+		return nil, err
+	}
+	return laps, nil
+}
+
+// Zones returns the heart rate and power zone distributions of an activity
+//
+// Zones are only available for activities owned by the authenticated athlete with a Strava subscription
+func (s *ActivityService) Zones(ctx context.Context, activityID int64) ([]*ActivityZone, error) {
+	uri := fmt.Sprintf("activities/%d/zones", activityID)
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	var zones []*ActivityZone
+	if err = s.client.do(req, &zones); err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// Comments returns the comments on an activity
+func (s *ActivityService) Comments(
+	ctx context.Context, activityID int64, spec activity.Pagination) ([]*Comment, error) {
+	return paginate[*Comment](ctx, s.client, fmt.Sprintf("activities/%d/comments", activityID), spec)
+}
+
+// Kudoers returns the athletes who kudoed an activity
+func (s *ActivityService) Kudoers(
+	ctx context.Context, activityID int64, spec activity.Pagination) ([]*Athlete, error) {
+	return paginate[*Athlete](ctx, s.client, fmt.Sprintf("activities/%d/kudos", activityID), spec)
+}
+
 // Export exports an activity in the GPX format
+//
+// Heart rate, cadence, temperature, and power are included as GPX extensions if available
 func (s *ActivityService) Export(ctx context.Context, activityID int64) (*activity.Export, error) {
-	act, err := s.Activity(ctx, activityID, "latlng", "time", "altitude")
+	act, err := s.Activity(ctx, activityID, "latlng", "time", "altitude", "heartrate", "cadence", "temp", "watts")
 	if err != nil {
 		return nil, err
 	}
@@ -317,6 +457,43 @@ func (s *ActivityService) Export(ctx context.Context, activityID int64) (*activi
 	return exp, nil
 }
 
+// Create a manual activity for the authenticated athlete
+func (s *ActivityService) Create(ctx context.Context, act *CreatableActivity) (*Activity, error) {
+	if act == nil || act.Name == "" || act.SportType == "" {
+		return nil, errors.New("missing activity, name, or sport type")
+	}
+	if act.StartDateLocal.IsZero() || act.ElapsedTime <= 0 {
+		return nil, errors.New("missing start date or elapsed time")
+	}
+	form := url.Values{}
+	form.Set("name", act.Name)
+	form.Set("sport_type", act.SportType)
+	form.Set("start_date_local", act.StartDateLocal.Format(time.RFC3339))
+	form.Set("elapsed_time", strconv.FormatInt(int64(act.ElapsedTime.Seconds()), 10))
+	if act.Distance > 0 {
+		form.Set("distance", strconv.FormatFloat(act.Distance.Meters(), 'f', -1, 64))
+	}
+	if act.Description != "" {
+		form.Set("description", act.Description)
+	}
+	if act.Trainer {
+		form.Set("trainer", "1")
+	}
+	if act.Commute {
+		form.Set("commute", "1")
+	}
+	req, err := s.client.newAPIRequest(ctx, http.MethodPost, "activities", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res := new(Activity)
+	if err = s.client.do(req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // Update the given activity owned by the authenticated athlete
 func (s *ActivityService) Update(ctx context.Context, act *UpdatableActivity) (*Activity, error) {
 	var buf bytes.Buffer
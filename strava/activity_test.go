@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/martinlindhe/unit"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bzimmer/activity"
@@ -329,6 +331,74 @@ func TestStreamSets(t *testing.T) {
 	s := client.Activity.StreamSets()
 	a.NotNil(s)
 	a.Equal(11, len(s))
+	names := client.Activity.StreamSetNames()
+	a.Equal(11, len(names))
+	a.Equal("altitude", names[0])
+	a.Equal("watts", names[10])
+}
+
+func TestStreamsWithOptions(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name  string
+		opts  []strava.APIOption
+		query url.Values
+		err   string
+	}{
+		{
+			name:  "no options",
+			query: url.Values{"key_by_type": {"true"}},
+		},
+		{
+			name: "resolution and series type",
+			opts: []strava.APIOption{
+				strava.WithResolution(strava.ResolutionLow),
+				strava.WithSeriesType(strava.SeriesTypeDistance),
+			},
+			query: url.Values{
+				"key_by_type": {"true"},
+				"resolution":  {"low"},
+				"series_type": {"distance"},
+			},
+		},
+		{
+			name: "invalid resolution",
+			opts: []strava.APIOption{strava.WithResolution("ultra")},
+			err:  "invalid resolution",
+		},
+		{
+			name: "invalid series type",
+			opts: []strava.APIOption{strava.WithSeriesType("heartrate")},
+			err:  "invalid series type",
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var client *strava.Client
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.HandleFunc("/activities/8002/streams/", func(w http.ResponseWriter, r *http.Request) {
+					a.Equal("/activities/8002/streams/"+strings.Join(client.Activity.StreamSetNames(), ","), r.URL.Path)
+					a.Equal(tt.query, r.URL.Query())
+					http.ServeFile(w, r, "testdata/streams_four.json")
+				})
+			})
+			defer svr.Close()
+			streams, err := client.Activity.AllStreams(context.TODO(), 8002, tt.opts...)
+			if tt.err != "" {
+				a.Error(err)
+				a.Nil(streams)
+				a.Contains(err.Error(), tt.err)
+				return
+			}
+			a.NoError(err)
+			a.NotNil(streams)
+			a.Equal(int64(8002), streams.ActivityID)
+		})
+	}
 }
 
 func TestPhotos(t *testing.T) {
@@ -436,6 +506,97 @@ func TestUpload(t *testing.T) {
 	}
 }
 
+func TestUploadMetadata(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	trainer := true
+	for _, tt := range []struct {
+		name   string
+		err    bool
+		opts   []strava.UploadOption
+		md     *activity.UploadMetadata
+		fields map[string]string
+	}{
+		{
+			name:   "no metadata",
+			fields: map[string]string{"filename": "LongHike.gpx", "data_type": "gpx", "name": "", "external_id": ""},
+		},
+		{
+			name: "metadata",
+			opts: []strava.UploadOption{
+				strava.WithUploadName("Long Hike"),
+				strava.WithUploadDescription("up and over"),
+				strava.WithUploadTrainer(false),
+				strava.WithUploadCommute(true),
+				strava.WithExternalID("hike-20210101"),
+			},
+			fields: map[string]string{
+				"filename":    "LongHike.gpx",
+				"data_type":   "gpx",
+				"name":        "Long Hike",
+				"description": "up and over",
+				"trainer":     "0",
+				"commute":     "1",
+				"external_id": "hike-20210101",
+			},
+		},
+		{
+			name: "file metadata",
+			md: &activity.UploadMetadata{
+				Name:       "Short Hike",
+				ExternalID: "hike-20210102",
+				Trainer:    &trainer,
+			},
+			fields: map[string]string{
+				"filename":    "LongHike.gpx",
+				"name":        "Short Hike",
+				"description": "",
+				"trainer":     "1",
+				"commute":     "",
+				"external_id": "hike-20210102",
+			},
+		},
+		{
+			name: "empty external id",
+			err:  true,
+			opts: []strava.UploadOption{strava.WithExternalID("")},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.HandleFunc("/uploads", func(w http.ResponseWriter, r *http.Request) {
+					a.NoError(r.ParseMultipartForm(1024 * 1024))
+					for key, value := range tt.fields {
+						a.Equal(value, r.FormValue(key), key)
+					}
+					enc := json.NewEncoder(w)
+					a.NoError(enc.Encode(&strava.Upload{ID: 12345, ExternalID: r.FormValue("external_id")}))
+				})
+			})
+			defer svr.Close()
+			file := &activity.File{
+				Name: "LongHike.gpx", Format: activity.FormatGPX, Reader: strings.NewReader("<gpx/>"), Metadata: tt.md}
+			var upload activity.Upload
+			var err error
+			switch {
+			case tt.md != nil:
+				upload, err = client.Uploader().Upload(context.Background(), file)
+			default:
+				upload, err = client.Activity.Upload(context.Background(), file, tt.opts...)
+			}
+			if tt.err {
+				a.Error(err)
+				a.Nil(upload)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.fields["external_id"], upload.(*strava.Upload).ExternalID)
+		})
+	}
+}
+
 func TestExporter(t *testing.T) {
 	t.Parallel()
 	a := assert.New(t)
@@ -567,3 +728,162 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestLaps(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name   string
+		before func(mux *http.ServeMux)
+		after  func(laps []*strava.Lap, err error)
+	}{
+		{
+			name: "valid laps",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/activities/8002/laps", func(w http.ResponseWriter, r *http.Request) {
+					http.ServeFile(w, r, "testdata/laps.json")
+				})
+			},
+			after: func(laps []*strava.Lap, err error) {
+				a.NoError(err)
+				a.Len(laps, 2)
+				a.Equal(2, laps[1].LapIndex)
+			},
+		},
+		{
+			name: "activity not found",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/activities/8002/laps", func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				})
+			},
+			after: func(laps []*strava.Lap, err error) {
+				a.Error(err)
+				a.Nil(laps)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(tt.before)
+			defer svr.Close()
+			tt.after(client.Activity.Laps(context.TODO(), 8002))
+		})
+	}
+}
+
+func TestZones(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClientMust(func(mux *http.ServeMux) {
+		mux.HandleFunc("/activities/8002/zones", func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, "testdata/zones.json")
+		})
+	})
+	defer svr.Close()
+	zones, err := client.Activity.Zones(context.TODO(), 8002)
+	a.NoError(err)
+	a.Len(zones, 2)
+	a.Equal(strava.ZoneTypeHeartRate, zones[0].Type)
+	a.Len(zones[0].DistributionBuckets, 5)
+	a.Equal(float64(1802), zones[0].DistributionBuckets[1].Time.Seconds())
+	a.Equal(strava.ZoneTypePower, zones[1].Type)
+	a.Equal(float64(-1), zones[1].DistributionBuckets[7].Max)
+}
+
+func TestCommentsAndKudoers(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClientMust(func(mux *http.ServeMux) {
+		mux.Handle("/activities/8002/comments", &ManyHandler{
+			Total:    3,
+			Filename: "testdata/comment.json",
+		})
+		mux.Handle("/activities/8002/kudos", &ManyHandler{
+			Filename: "testdata/kudoer.json",
+		})
+	})
+	defer svr.Close()
+
+	comments, err := client.Activity.Comments(context.TODO(), 8002, activity.Pagination{Total: 10})
+	a.NoError(err)
+	a.Len(comments, 3)
+	a.Equal("Peter", comments[0].Athlete.Firstname)
+
+	kudoers, err := client.Activity.Kudoers(context.TODO(), 8002, activity.Pagination{Total: 125})
+	a.NoError(err)
+	a.Len(kudoers, 125)
+	a.Equal("Peter", kudoers[0].Firstname)
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	start := time.Date(2024, time.March, 3, 7, 30, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name string
+		act  *strava.CreatableActivity
+		err  string
+	}{
+		{
+			name: "nil activity",
+			err:  "missing activity, name, or sport type",
+		},
+		{
+			name: "missing sport type",
+			act:  &strava.CreatableActivity{Name: "Yoga"},
+			err:  "missing activity, name, or sport type",
+		},
+		{
+			name: "missing elapsed time",
+			act:  &strava.CreatableActivity{Name: "Yoga", SportType: "Yoga", StartDateLocal: start},
+			err:  "missing start date or elapsed time",
+		},
+		{
+			name: "yoga",
+			act: &strava.CreatableActivity{
+				Name:           "Yoga",
+				SportType:      "Yoga",
+				StartDateLocal: start,
+				ElapsedTime:    unit.Duration(3600),
+				Description:    "morning flow",
+				Trainer:        true,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.HandleFunc("/activities", func(w http.ResponseWriter, r *http.Request) {
+					a.Equal(http.MethodPost, r.Method)
+					a.Equal("Yoga", r.FormValue("name"))
+					a.Equal("Yoga", r.FormValue("sport_type"))
+					a.Equal("2024-03-03T07:30:00Z", r.FormValue("start_date_local"))
+					a.Equal("3600", r.FormValue("elapsed_time"))
+					a.Equal("morning flow", r.FormValue("description"))
+					a.Equal("1", r.FormValue("trainer"))
+					a.Equal("", r.FormValue("commute"))
+					a.Equal("", r.FormValue("distance"))
+					http.ServeFile(w, r, "testdata/activity.json")
+				})
+			})
+			defer svr.Close()
+			act, err := client.Activity.Create(context.Background(), tt.act)
+			if tt.err != "" {
+				a.Error(err)
+				a.Nil(act)
+				a.Contains(err.Error(), tt.err)
+				return
+			}
+			a.NoError(err)
+			a.NotNil(act)
+		})
+	}
+}
@@ -2,8 +2,12 @@ package strava
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // AthleteService is the API for athlete endpoints
@@ -39,3 +43,39 @@ func (s *AthleteService) Stats(ctx context.Context, id int) (*Stats, error) {
 	}
 	return sts, nil
 }
+
+// Zones returns the heart rate and power zones of the authenticated athlete
+func (s *AthleteService) Zones(ctx context.Context) (*AthleteZones, error) {
+	uri := "athlete/zones"
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	zones := &AthleteZones{}
+	err = s.client.do(req, zones)
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// Update the authenticated athlete
+func (s *AthleteService) Update(ctx context.Context, ath *UpdatableAthlete) (*Athlete, error) {
+	if ath == nil || ath.Weight <= 0 {
+		return nil, errors.New("missing athlete or weight")
+	}
+	form := url.Values{}
+	form.Set("weight", strconv.FormatFloat(ath.Weight.Kilograms(), 'f', -1, 64))
+	uri := "athlete"
+	req, err := s.client.newAPIRequest(ctx, http.MethodPut, uri, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res := &Athlete{}
+	err = s.client.do(req, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -98,3 +98,68 @@ func TestAthleteStats(t *testing.T) {
 		})
 	}
 }
+
+func TestAthleteZones(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClientMust(func(mux *http.ServeMux) {
+		mux.HandleFunc("/athlete/zones", func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, "testdata/athlete_zones.json")
+		})
+	})
+	defer svr.Close()
+	zones, err := client.Athlete.Zones(context.TODO())
+	a.NoError(err)
+	a.NotNil(zones)
+	a.Len(zones.HeartRate.Zones, 5)
+	a.Len(zones.Power.Zones, 7)
+	a.Equal(float64(-1), zones.Power.Zones[6].Max)
+}
+
+func TestAthleteUpdate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name string
+		ath  *strava.UpdatableAthlete
+		err  bool
+	}{
+		{
+			name: "nil athlete",
+			err:  true,
+		},
+		{
+			name: "zero weight",
+			ath:  &strava.UpdatableAthlete{},
+			err:  true,
+		},
+		{
+			name: "weight",
+			ath:  &strava.UpdatableAthlete{Weight: 72.5 * unit.Kilogram},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.HandleFunc("/athlete", func(w http.ResponseWriter, r *http.Request) {
+					a.Equal(http.MethodPut, r.Method)
+					a.Equal("72.5", r.FormValue("weight"))
+					http.ServeFile(w, r, "testdata/athlete.json")
+				})
+			})
+			defer svr.Close()
+			ath, err := client.Athlete.Update(context.TODO(), tt.ath)
+			if tt.err {
+				a.Error(err)
+				a.Nil(ath)
+				return
+			}
+			a.NoError(err)
+			a.NotNil(ath)
+		})
+	}
+}
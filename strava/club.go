@@ -0,0 +1,49 @@
+package strava
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bzimmer/activity"
+)
+
+// ClubService is the API for club endpoints
+type ClubService service
+
+// Clubs returns the clubs of which the authenticated athlete is a member
+func (s *ClubService) Clubs(ctx context.Context, spec activity.Pagination) ([]*Club, error) {
+	return paginate[*Club](ctx, s.client, "athlete/clubs", spec)
+}
+
+// Club returns the club specified by id
+func (s *ClubService) Club(ctx context.Context, clubID int) (*Club, error) {
+	uri := fmt.Sprintf("clubs/%d", clubID)
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	club := &Club{}
+	if err = s.client.do(req, club); err != nil {
+		return nil, err
+	}
+	return club, nil
+}
+
+// Members returns the members of the club
+func (s *ClubService) Members(ctx context.Context, clubID int, spec activity.Pagination) ([]*ClubAthlete, error) {
+	return paginate[*ClubAthlete](ctx, s.client, fmt.Sprintf("clubs/%d/members", clubID), spec)
+}
+
+// Admins returns the administrators of the club
+func (s *ClubService) Admins(ctx context.Context, clubID int, spec activity.Pagination) ([]*ClubAthlete, error) {
+	return paginate[*ClubAthlete](ctx, s.client, fmt.Sprintf("clubs/%d/admins", clubID), spec)
+}
+
+// Activities returns the recent activities of the club's members
+//
+// Strava returns only a summary of each activity, ids and start dates are not available
+func (s *ClubService) Activities(
+	ctx context.Context, clubID int, spec activity.Pagination) ([]*ClubActivity, error) {
+	return paginate[*ClubActivity](ctx, s.client, fmt.Sprintf("clubs/%d/activities", clubID), spec)
+}
@@ -0,0 +1,168 @@
+package strava_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity"
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestClub(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name   string
+		before func(mux *http.ServeMux)
+		after  func(club *strava.Club, err error)
+	}{
+		{
+			name: "valid club",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/clubs/1", func(w http.ResponseWriter, r *http.Request) {
+					http.ServeFile(w, r, "testdata/club.json")
+				})
+			},
+			after: func(club *strava.Club, err error) {
+				a.NoError(err)
+				a.NotNil(club)
+				a.Equal(1, club.ID)
+				a.Equal(116, club.MemberCount)
+			},
+		},
+		{
+			name: "club not found",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/clubs/1", func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				})
+			},
+			after: func(club *strava.Club, err error) {
+				a.Error(err)
+				a.Nil(club)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(tt.before)
+			defer svr.Close()
+			tt.after(client.Club.Club(context.TODO(), 1))
+		})
+	}
+}
+
+func TestClubs(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name       string
+		pagination activity.Pagination
+		after      func(clubs []*strava.Club, err error)
+	}{
+		{
+			name:       "total less than PageSize",
+			pagination: activity.Pagination{Total: 12},
+			after: func(clubs []*strava.Club, err error) {
+				a.NoError(err)
+				a.Len(clubs, 12)
+			},
+		},
+		{
+			name:       "total greater than PageSize",
+			pagination: activity.Pagination{Total: 234},
+			after: func(clubs []*strava.Club, err error) {
+				a.NoError(err)
+				a.Len(clubs, 234)
+			},
+		},
+		{
+			name:       "negative total",
+			pagination: activity.Pagination{Total: -1},
+			after: func(clubs []*strava.Club, err error) {
+				a.Error(err)
+				a.Nil(clubs)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.Handle("/athlete/clubs", &ManyHandler{
+					Filename: "testdata/club.json",
+				})
+			})
+			defer svr.Close()
+			tt.after(client.Club.Clubs(context.TODO(), tt.pagination))
+		})
+	}
+}
+
+func TestClubMembers(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name  string
+		uri   string
+		query func(client *strava.Client) ([]*strava.ClubAthlete, error)
+	}{
+		{
+			name: "members",
+			uri:  "/clubs/1/members",
+			query: func(client *strava.Client) ([]*strava.ClubAthlete, error) {
+				return client.Club.Members(context.TODO(), 1, activity.Pagination{Total: 7})
+			},
+		},
+		{
+			name: "admins",
+			uri:  "/clubs/1/admins",
+			query: func(client *strava.Client) ([]*strava.ClubAthlete, error) {
+				return client.Club.Admins(context.TODO(), 1, activity.Pagination{Total: 7})
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.Handle(tt.uri, &ManyHandler{
+					Filename: "testdata/club_athlete.json",
+				})
+			})
+			defer svr.Close()
+			athletes, err := tt.query(client)
+			a.NoError(err)
+			a.Len(athletes, 7)
+			a.Equal("Peter", athletes[0].Firstname)
+		})
+	}
+}
+
+func TestClubActivities(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClientMust(func(mux *http.ServeMux) {
+		mux.Handle("/clubs/1/activities", &ManyHandler{
+			Total:    15,
+			Filename: "testdata/club_activity.json",
+		})
+	})
+	defer svr.Close()
+	acts, err := client.Club.Activities(context.TODO(), 1, activity.Pagination{Total: 50})
+	a.NoError(err)
+	a.Len(acts, 15)
+	a.Equal("Peter", acts[0].Athlete.Firstname)
+	a.Equal(float64(2641.7), acts[0].Distance.Meters())
+}
@@ -0,0 +1,206 @@
+package strava
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	dispatchCapacity   = 1024
+	dispatchWorkers    = 4
+	dispatchRetries    = 3
+	dispatchBackoff    = time.Second
+	dispatchMaxBackoff = time.Minute
+)
+
+// WebhookDeadLetterFunc receives messages which failed processing after all retries
+type WebhookDeadLetterFunc func(msg *WebhookMessage, err error)
+
+// A DispatcherOption allows configuring the dispatcher
+type DispatcherOption func(d *Dispatcher)
+
+// WithQueue sets the queue backend, the default is an in-memory queue
+func WithQueue(queue WebhookQueue) DispatcherOption {
+	return func(d *Dispatcher) {
+		if queue != nil {
+			d.queue = queue
+		}
+	}
+}
+
+// WithWorkers sets the number of concurrent workers processing messages
+func WithWorkers(workers int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if workers > 0 {
+			d.workers = workers
+		}
+	}
+}
+
+// WithRetries sets the number of retries after a failed attempt to process a message
+func WithRetries(retries int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if retries >= 0 {
+			d.retries = retries
+		}
+	}
+}
+
+// WithBackoff sets the initial and maximum duration between retries, the duration doubles after each attempt
+func WithBackoff(initial, maximum time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		if initial > 0 {
+			d.backoff = initial
+		}
+		if maximum >= d.backoff {
+			d.maxBackoff = maximum
+		}
+	}
+}
+
+// WithDeadLetter sets the function receiving messages which exhausted their retries
+func WithDeadLetter(f WebhookDeadLetterFunc) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.deadLetter = f
+	}
+}
+
+// WithDispatchHook adds a hook called after each message is processed
+//
+// The result's outcome is WebhookAccepted if the subscriber processed the message or
+// WebhookFailed if it was dead-lettered.
+func WithDispatchHook(hook WebhookHook) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.hooks = append(d.hooks, hook)
+	}
+}
+
+// Dispatcher processes webhook messages asynchronously
+//
+// The dispatcher is a WebhookSubscriber which enqueues received messages, allowing the webhook
+// handler to acknowledge Strava immediately, and delivers them to the wrapped subscriber from a
+// pool of workers. Subscription requests are delegated to the wrapped subscriber synchronously.
+type Dispatcher struct {
+	sub        WebhookSubscriber
+	queue      WebhookQueue
+	workers    int
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	deadLetter WebhookDeadLetterFunc
+	hooks      []WebhookHook
+}
+
+// NewDispatcher returns a new dispatcher delivering messages to the subscriber
+func NewDispatcher(sub WebhookSubscriber, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		sub:        sub,
+		workers:    dispatchWorkers,
+		retries:    dispatchRetries,
+		backoff:    dispatchBackoff,
+		maxBackoff: dispatchMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.queue == nil {
+		d.queue = NewMemoryQueue(dispatchCapacity)
+	}
+	return d
+}
+
+// SubscriptionRequest delegates to the wrapped subscriber
+func (d *Dispatcher) SubscriptionRequest(challenge, verify string) error {
+	return d.sub.SubscriptionRequest(challenge, verify)
+}
+
+// MessageReceived enqueues the message for processing
+func (d *Dispatcher) MessageReceived(msg *WebhookMessage) error {
+	return d.queue.Push(msg)
+}
+
+// Run processes messages until the context is canceled
+//
+// On cancellation no new messages are dequeued and Run returns once the in-flight messages
+// complete their current attempt. In-flight messages awaiting a retry are requeued or, if
+// they cannot be requeued, dead-lettered.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < d.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (d *Dispatcher) work(ctx context.Context) {
+	for {
+		env, err := d.queue.Pop(ctx)
+		if err != nil {
+			return
+		}
+		d.process(ctx, env)
+	}
+}
+
+func (d *Dispatcher) process(ctx context.Context, env *WebhookEnvelope) {
+	started := time.Now()
+	backoff := d.backoff
+	for {
+		env.Attempts++
+		err := d.sub.MessageReceived(env.Message)
+		if err == nil {
+			_ = d.queue.Ack(env)
+			d.done(env, started, nil)
+			return
+		}
+		if env.Attempts > d.retries {
+			d.fail(env, started, err)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			if err = d.queue.Requeue(env); err != nil {
+				d.fail(env, started, errors.Join(ctx.Err(), err))
+			}
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, d.maxBackoff)
+	}
+}
+
+// fail dead-letters the message
+func (d *Dispatcher) fail(env *WebhookEnvelope, started time.Time, err error) {
+	if d.deadLetter != nil {
+		d.deadLetter(env.Message, err)
+	}
+	_ = d.queue.Ack(env)
+	d.done(env, started, err)
+}
+
+// done calls the hooks with the outcome of processing the message
+func (d *Dispatcher) done(env *WebhookEnvelope, started time.Time, err error) {
+	if len(d.hooks) == 0 {
+		return
+	}
+	res := &WebhookResult{
+		Message:  env.Message,
+		Outcome:  WebhookAccepted,
+		Err:      err,
+		Received: started,
+		Duration: time.Since(started),
+	}
+	if err != nil {
+		res.Outcome = WebhookFailed
+	}
+	for _, hook := range d.hooks {
+		hook(res)
+	}
+}
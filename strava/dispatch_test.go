@@ -0,0 +1,164 @@
+package strava_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+type countingSubscriber struct {
+	mu       sync.Mutex
+	failures map[int64]int
+	received []*strava.WebhookMessage
+	attempts atomic.Int32
+}
+
+func (c *countingSubscriber) SubscriptionRequest(_, _ string) error {
+	return nil
+}
+
+func (c *countingSubscriber) MessageReceived(msg *strava.WebhookMessage) error {
+	c.attempts.Add(1)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures[msg.ObjectID] > 0 {
+		c.failures[msg.ObjectID]--
+		return errors.New("failed")
+	}
+	c.received = append(c.received, msg)
+	return nil
+}
+
+func (c *countingSubscriber) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.received)
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name       string
+		failures   map[int64]int
+		retries    int
+		received   int
+		deadLetter int
+		attempts   int
+	}{
+		{
+			name:     "success",
+			failures: map[int64]int{},
+			retries:  2,
+			received: 3,
+			attempts: 3,
+		},
+		{
+			name:     "retry then success",
+			failures: map[int64]int{2: 2},
+			retries:  2,
+			received: 3,
+			attempts: 5,
+		},
+		{
+			name:       "dead letter",
+			failures:   map[int64]int{2: 5},
+			retries:    1,
+			received:   2,
+			deadLetter: 1,
+			attempts:   4,
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var dead atomic.Int32
+			sub := &countingSubscriber{failures: tt.failures}
+			d := strava.NewDispatcher(sub,
+				strava.WithWorkers(2),
+				strava.WithRetries(tt.retries),
+				strava.WithBackoff(time.Millisecond, 5*time.Millisecond),
+				strava.WithDeadLetter(func(msg *strava.WebhookMessage, err error) {
+					a.Equal(int64(2), msg.ObjectID)
+					a.Error(err)
+					dead.Add(1)
+				}))
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() { done <- d.Run(ctx) }()
+			for id := int64(1); id <= 3; id++ {
+				a.NoError(d.MessageReceived(&strava.WebhookMessage{ObjectID: id}))
+			}
+			a.Eventually(func() bool {
+				return sub.count() == tt.received && int(dead.Load()) == tt.deadLetter
+			}, time.Second, time.Millisecond)
+			cancel()
+			a.NoError(<-done)
+			a.Equal(tt.attempts, int(sub.attempts.Load()))
+		})
+	}
+}
+
+func TestDispatcherShutdown(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	q := strava.NewMemoryQueue(1)
+	sub := &countingSubscriber{failures: map[int64]int{1: 100}}
+	d := strava.NewDispatcher(sub,
+		strava.WithQueue(q), strava.WithRetries(100), strava.WithBackoff(time.Hour, time.Hour))
+	a.NoError(d.MessageReceived(&strava.WebhookMessage{ObjectID: 1}))
+	a.ErrorIs(d.MessageReceived(&strava.WebhookMessage{ObjectID: 2}), strava.ErrQueueFull)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+	a.Eventually(func() bool { return sub.attempts.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		a.NoError(err)
+	case <-time.After(time.Second):
+		a.Fail("dispatcher did not shutdown")
+	}
+	// the in-flight message was requeued
+	a.Equal(1, q.Len())
+	env, err := q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(int64(1), env.Message.ObjectID)
+}
+
+func TestDispatcherHandler(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	q := strava.NewMemoryQueue(1)
+	d := strava.NewDispatcher(&TestSubscriber{fail: true}, strava.WithQueue(q))
+	mux := http.NewServeMux()
+	mux.Handle("/webhook", strava.NewWebhookHandler(d))
+
+	body := `{"aspect_type": "create", "object_id": 1, "object_type": "activity", "owner_id": 1}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	mux.ServeHTTP(w, req)
+	// the subscriber fails but the message is only enqueued so the handler acks
+	a.Equal(http.StatusOK, w.Code)
+	a.Equal(1, q.Len())
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	mux.ServeHTTP(w, req)
+	a.Equal(http.StatusInternalServerError, w.Code)
+}
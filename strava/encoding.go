@@ -66,12 +66,21 @@ func (a *Activity) GPX() (*gpx.GPX, error) {
 }
 
 // GPX representation of a route
+//
+// If the route has streams the full fidelity geometry, including elevation, is used
+// otherwise the route's polyline is decoded
 func (r *Route) GPX() (*gpx.GPX, error) {
-	ls, err := r.Map.LineString()
-	if err != nil {
-		return nil, err
+	var rte *gpx.RteType
+	switch {
+	case r.Streams != nil && r.Streams.LatLng != nil:
+		rte = r.toRteFromStreams()
+	default:
+		ls, err := r.Map.LineString()
+		if err != nil {
+			return nil, err
+		}
+		rte = gpx.NewRteType(ls)
 	}
-	rte := gpx.NewRteType(ls)
 	rte.Name = r.Name
 	rte.Desc = r.Description
 	rte.Link = []*gpx.LinkType{
@@ -85,6 +94,20 @@ func (r *Route) GPX() (*gpx.GPX, error) {
 	return x, nil
 }
 
+func (r *Route) toRteFromStreams() *gpx.RteType {
+	points := make([]*gpx.WptType, len(r.Streams.LatLng.Data))
+	for i, latlng := range r.Streams.LatLng.Data {
+		points[i] = &gpx.WptType{
+			Lat: latlng[0],
+			Lon: latlng[1],
+		}
+		if r.Streams.Elevation != nil && i < len(r.Streams.Elevation.Data) {
+			points[i].Ele = r.Streams.Elevation.Data[i].Meters()
+		}
+	}
+	return &gpx.RteType{RtePt: points}
+}
+
 func (a *Activity) toGPXFromStreams() (*gpx.GPX, error) {
 	if a.Streams == nil {
 		return nil, errors.New("no streams available for gpx encoding")
@@ -121,5 +144,23 @@ func (a *Activity) toGPXFromStreams() (*gpx.GPX, error) {
 			},
 		},
 	}
+	a.Streams.sensors().Encode(x, points)
 	return x, nil
 }
+
+func (s *Streams) sensors() *activity.SensorStreams {
+	sensors := &activity.SensorStreams{}
+	if s.HeartRate != nil {
+		sensors.HeartRate = s.HeartRate.Data
+	}
+	if s.Cadence != nil {
+		sensors.Cadence = s.Cadence.Data
+	}
+	if s.Temperature != nil {
+		sensors.Temperature = s.Temperature.Data
+	}
+	if s.Watts != nil {
+		sensors.Power = s.Watts.Data
+	}
+	return sensors
+}
@@ -1,12 +1,16 @@
 package strava_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
 )
 
 func TestGPXRoute(t *testing.T) {
@@ -34,6 +38,16 @@ func TestGPXRoute(t *testing.T) {
 			routepoints: 2076,
 			desc:        "between Deer Park and Obstruction Point Road",
 		},
+		{
+			id:          26587226,
+			name:        "route with streams",
+			streams:     []string{"latlng", "distance", "altitude"},
+			tracks:      0,
+			routes:      1,
+			trackpoints: 0,
+			routepoints: 4,
+			desc:        "between Deer Park and Obstruction Point Road",
+		},
 	}
 
 	for i := range tests {
@@ -44,11 +58,18 @@ func TestGPXRoute(t *testing.T) {
 				mux.HandleFunc("/routes/26587226", func(w http.ResponseWriter, r *http.Request) {
 					http.ServeFile(w, r, "testdata/route.json")
 				})
+				mux.HandleFunc("/routes/26587226/streams", func(w http.ResponseWriter, r *http.Request) {
+					http.ServeFile(w, r, "testdata/route_streams.json")
+				})
 			})
 			defer svr.Close()
 			rte, err := client.Route.Route(context.Background(), tt.id)
 			a.NoError(err)
 			a.NotNil(rte)
+			if len(tt.streams) > 0 {
+				rte.Streams, err = client.Route.Streams(context.Background(), tt.id)
+				a.NoError(err)
+			}
 
 			gpx, err := rte.GPX()
 			switch tt.err {
@@ -61,6 +82,9 @@ func TestGPXRoute(t *testing.T) {
 				a.Equal(tt.tracks, len(gpx.Trk))
 				a.Equal(tt.routes, len(gpx.Rte))
 				a.Equal(tt.routepoints, len(gpx.Rte[0].RtePt))
+				if len(tt.streams) > 0 {
+					a.Equal(1642.2, gpx.Rte[0].RtePt[0].Ele)
+				}
 				if tt.desc != "" {
 					a.Contains(gpx.Rte[0].Desc, tt.desc)
 				}
@@ -148,3 +172,35 @@ func TestGPXActivity(t *testing.T) {
 		})
 	}
 }
+
+func TestGPXActivitySensors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	act := &strava.Activity{
+		ID:        8002,
+		Name:      "Ride with sensors",
+		StartDate: time.Date(2024, time.May, 4, 8, 0, 0, 0, time.UTC),
+		Streams: &strava.Streams{
+			LatLng:    &strava.CoordinateStream{Data: []strava.Coordinates{{47.61, -122.33}, {47.62, -122.34}}},
+			Time:      &strava.Stream{Data: []float64{0, 1}},
+			HeartRate: &strava.Stream{Data: []float64{121, 125}},
+			Cadence:   &strava.Stream{Data: []float64{85, 87}},
+			Watts:     &strava.Stream{Data: []float64{210, 230}},
+		},
+	}
+	x, err := act.GPX()
+	a.NoError(err)
+	a.NotNil(x)
+	pts := x.Trk[0].TrkSeg[0].TrkPt
+	a.Len(pts, 2)
+	a.NotNil(pts[1].Extensions)
+	a.Contains(string(pts[1].Extensions.XML), "<gpxtpx:hr>125</gpxtpx:hr>")
+	a.Contains(string(pts[1].Extensions.XML), "<gpxtpx:cad>87</gpxtpx:cad>")
+	a.Contains(string(pts[1].Extensions.XML), "<pwr:Watts>230</pwr:Watts>")
+
+	var buf bytes.Buffer
+	a.NoError(x.Write(&buf))
+	a.Contains(buf.String(), `xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v2"`)
+	a.Contains(buf.String(), `xmlns:pwr="http://www.garmin.com/xmlschemas/PowerExtension/v1"`)
+}
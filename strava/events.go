@@ -0,0 +1,250 @@
+package strava
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrUnhandledDeauthorization occurs when an athlete deauthorization is received without a handler
+var ErrUnhandledDeauthorization = errors.New("unhandled athlete deauthorization")
+
+// EventType identifies the kind of a webhook event
+type EventType string
+
+const (
+	// EventActivityCreated occurs when an athlete creates an activity
+	EventActivityCreated EventType = "activity:create"
+	// EventActivityUpdated occurs when an athlete changes an activity's title, type or privacy
+	EventActivityUpdated EventType = "activity:update"
+	// EventActivityDeleted occurs when an athlete deletes an activity
+	EventActivityDeleted EventType = "activity:delete"
+	// EventAthleteDeauthorized occurs when an athlete revokes access to the application
+	EventAthleteDeauthorized EventType = "athlete:deauthorize"
+)
+
+// WebhookEvent is a typed webhook message
+type WebhookEvent interface {
+	// EventType returns the type of the event
+	EventType() EventType
+}
+
+// ActivityEvent contains the details common to all activity events
+type ActivityEvent struct {
+	ActivityID     int64           `json:"activity_id"`
+	AthleteID      int             `json:"athlete_id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventTime      time.Time       `json:"event_time"`
+	Message        *WebhookMessage `json:"-"`
+}
+
+// ActivityCreated is the event for a newly created activity
+type ActivityCreated struct {
+	ActivityEvent
+}
+
+// ActivityUpdated is the event for an updated activity
+//
+// Only the fields changed by the athlete are non-nil
+type ActivityUpdated struct {
+	ActivityEvent
+	Title   *string `json:"title,omitempty"`
+	Type    *string `json:"type,omitempty"`
+	Private *bool   `json:"private,omitempty"`
+}
+
+// ActivityDeleted is the event for a deleted activity
+type ActivityDeleted struct {
+	ActivityEvent
+}
+
+// AthleteDeauthorized is the event for an athlete revoking access to the application
+//
+// Strava requires all data for the athlete be deleted upon receipt of this event
+type AthleteDeauthorized struct {
+	AthleteID      int             `json:"athlete_id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventTime      time.Time       `json:"event_time"`
+	Message        *WebhookMessage `json:"-"`
+}
+
+// EventType returns the type of the event
+func (e *ActivityCreated) EventType() EventType {
+	return EventActivityCreated
+}
+
+// EventType returns the type of the event
+func (e *ActivityUpdated) EventType() EventType {
+	return EventActivityUpdated
+}
+
+// EventType returns the type of the event
+func (e *ActivityDeleted) EventType() EventType {
+	return EventActivityDeleted
+}
+
+// EventType returns the type of the event
+func (e *AthleteDeauthorized) EventType() EventType {
+	return EventAthleteDeauthorized
+}
+
+// Event returns the typed event for the message
+//
+// If the message is not a known event a nil event and nil error are returned
+func (m *WebhookMessage) Event() (WebhookEvent, error) {
+	switch m.ObjectType {
+	case "activity":
+		ae := ActivityEvent{
+			ActivityID:     m.ObjectID,
+			AthleteID:      m.OwnerID,
+			SubscriptionID: m.SubscriptionID,
+			EventTime:      time.Unix(int64(m.EventTime), 0),
+			Message:        m,
+		}
+		switch m.AspectType {
+		case "create":
+			return &ActivityCreated{ActivityEvent: ae}, nil
+		case "delete":
+			return &ActivityDeleted{ActivityEvent: ae}, nil
+		case "update":
+			evt := &ActivityUpdated{ActivityEvent: ae}
+			if title, ok := m.Updates["title"]; ok {
+				evt.Title = &title
+			}
+			if typ, ok := m.Updates["type"]; ok {
+				evt.Type = &typ
+			}
+			if private, ok := m.Updates["private"]; ok {
+				val, err := strconv.ParseBool(private)
+				if err != nil {
+					return nil, fmt.Errorf("invalid private value '%s': %w", private, err)
+				}
+				evt.Private = &val
+			}
+			return evt, nil
+		}
+	case "athlete":
+		// the only athlete update Strava sends is a deauthorization
+		if m.AspectType == "update" && m.Updates["authorized"] == "false" {
+			return &AthleteDeauthorized{
+				// for athlete events the object id is the athlete id
+				AthleteID:      int(m.ObjectID),
+				SubscriptionID: m.SubscriptionID,
+				EventTime:      time.Unix(int64(m.EventTime), 0),
+				Message:        m,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// WebhookRouter routes webhook messages to handlers registered by event type
+//
+// The router implements WebhookSubscriber so it can be used directly with
+// NewWebhookHandler or a Dispatcher. Deauthorization events must be handled;
+// if no handler is registered the message is rejected with ErrUnhandledDeauthorization.
+type WebhookRouter struct {
+	mu           sync.RWMutex
+	subscription func(challenge, verify string) error
+	created      []func(*ActivityCreated) error
+	updated      []func(*ActivityUpdated) error
+	deleted      []func(*ActivityDeleted) error
+	deauthorized []func(*AthleteDeauthorized) error
+	unhandled    func(*WebhookMessage) error
+}
+
+// NewWebhookRouter returns a new router with no handlers
+func NewWebhookRouter() *WebhookRouter {
+	return &WebhookRouter{}
+}
+
+// OnSubscriptionRequest sets the handler for the subscription request flow
+func (r *WebhookRouter) OnSubscriptionRequest(f func(challenge, verify string) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscription = f
+}
+
+// OnActivityCreated registers a handler for activity created events
+func (r *WebhookRouter) OnActivityCreated(f func(*ActivityCreated) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.created = append(r.created, f)
+}
+
+// OnActivityUpdated registers a handler for activity updated events
+func (r *WebhookRouter) OnActivityUpdated(f func(*ActivityUpdated) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updated = append(r.updated, f)
+}
+
+// OnActivityDeleted registers a handler for activity deleted events
+func (r *WebhookRouter) OnActivityDeleted(f func(*ActivityDeleted) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deleted = append(r.deleted, f)
+}
+
+// OnAthleteDeauthorized registers a handler for athlete deauthorization events
+func (r *WebhookRouter) OnAthleteDeauthorized(f func(*AthleteDeauthorized) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deauthorized = append(r.deauthorized, f)
+}
+
+// OnUnhandled sets the handler for messages which are not a known event
+func (r *WebhookRouter) OnUnhandled(f func(*WebhookMessage) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unhandled = f
+}
+
+// SubscriptionRequest receives a callback during the subscription request flow
+func (r *WebhookRouter) SubscriptionRequest(challenge, verify string) error {
+	r.mu.RLock()
+	f := r.subscription
+	r.mu.RUnlock()
+	if f == nil {
+		return nil
+	}
+	return f(challenge, verify)
+}
+
+// MessageReceived routes the message to the handlers registered for its event type
+func (r *WebhookRouter) MessageReceived(msg *WebhookMessage) error {
+	evt, err := msg.Event()
+	if err != nil {
+		return err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	switch e := evt.(type) {
+	case *ActivityCreated:
+		return route(r.created, e)
+	case *ActivityUpdated:
+		return route(r.updated, e)
+	case *ActivityDeleted:
+		return route(r.deleted, e)
+	case *AthleteDeauthorized:
+		if len(r.deauthorized) == 0 {
+			return fmt.Errorf("%w: athlete %d", ErrUnhandledDeauthorization, e.AthleteID)
+		}
+		return route(r.deauthorized, e)
+	}
+	if r.unhandled != nil {
+		return r.unhandled(msg)
+	}
+	return nil
+}
+
+func route[T WebhookEvent](handlers []func(T) error, evt T) error {
+	for _, f := range handlers {
+		if err := f(evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
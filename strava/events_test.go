@@ -0,0 +1,136 @@
+package strava_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestWebhookMessageEvent(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name  string
+		msg   *strava.WebhookMessage
+		err   bool
+		after func(evt strava.WebhookEvent)
+	}{
+		{
+			name: "activity created",
+			msg: &strava.WebhookMessage{
+				ObjectType: "activity", AspectType: "create", ObjectID: 1234, OwnerID: 88, EventTime: 1516126040},
+			after: func(evt strava.WebhookEvent) {
+				a.Equal(strava.EventActivityCreated, evt.EventType())
+				e := evt.(*strava.ActivityCreated)
+				a.Equal(int64(1234), e.ActivityID)
+				a.Equal(88, e.AthleteID)
+				a.Equal(time.Unix(1516126040, 0), e.EventTime)
+				a.NotNil(e.Message)
+			},
+		},
+		{
+			name: "activity updated",
+			msg: &strava.WebhookMessage{
+				ObjectType: "activity", AspectType: "update", ObjectID: 1234,
+				Updates: map[string]string{"title": "Morning Ride", "private": "true"}},
+			after: func(evt strava.WebhookEvent) {
+				a.Equal(strava.EventActivityUpdated, evt.EventType())
+				e := evt.(*strava.ActivityUpdated)
+				a.Equal("Morning Ride", *e.Title)
+				a.Nil(e.Type)
+				a.True(*e.Private)
+			},
+		},
+		{
+			name: "activity updated with invalid private",
+			msg: &strava.WebhookMessage{
+				ObjectType: "activity", AspectType: "update", Updates: map[string]string{"private": "maybe"}},
+			err: true,
+		},
+		{
+			name: "activity deleted",
+			msg:  &strava.WebhookMessage{ObjectType: "activity", AspectType: "delete", ObjectID: 1234},
+			after: func(evt strava.WebhookEvent) {
+				a.Equal(strava.EventActivityDeleted, evt.EventType())
+			},
+		},
+		{
+			name: "athlete deauthorized",
+			msg: &strava.WebhookMessage{
+				ObjectType: "athlete", AspectType: "update", ObjectID: 88, OwnerID: 88,
+				Updates: map[string]string{"authorized": "false"}},
+			after: func(evt strava.WebhookEvent) {
+				a.Equal(strava.EventAthleteDeauthorized, evt.EventType())
+				a.Equal(88, evt.(*strava.AthleteDeauthorized).AthleteID)
+			},
+		},
+		{
+			name: "unknown",
+			msg:  &strava.WebhookMessage{ObjectType: "athlete", AspectType: "create"},
+			after: func(evt strava.WebhookEvent) {
+				a.Nil(evt)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			evt, err := tt.msg.Event()
+			if tt.err {
+				a.Error(err)
+				return
+			}
+			a.NoError(err)
+			tt.after(evt)
+		})
+	}
+}
+
+func TestWebhookRouter(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var created, updated, deleted, deauthorized, unhandled int
+	r := strava.NewWebhookRouter()
+	a.NoError(r.SubscriptionRequest("challenge", "verify"))
+	a.NoError(r.MessageReceived(&strava.WebhookMessage{ObjectType: "activity", AspectType: "create"}))
+
+	// deauthorization events must be handled
+	deauth := &strava.WebhookMessage{
+		ObjectType: "athlete", AspectType: "update", ObjectID: 88, Updates: map[string]string{"authorized": "false"}}
+	a.ErrorIs(r.MessageReceived(deauth), strava.ErrUnhandledDeauthorization)
+
+	r.OnSubscriptionRequest(func(challenge, verify string) error {
+		if verify != "verify" {
+			return errors.New("invalid verify token")
+		}
+		return nil
+	})
+	r.OnActivityCreated(func(*strava.ActivityCreated) error { created++; return nil })
+	r.OnActivityUpdated(func(*strava.ActivityUpdated) error { updated++; return nil })
+	r.OnActivityDeleted(func(*strava.ActivityDeleted) error { deleted++; return errors.New("failed") })
+	r.OnAthleteDeauthorized(func(*strava.AthleteDeauthorized) error { deauthorized++; return nil })
+	r.OnUnhandled(func(*strava.WebhookMessage) error { unhandled++; return nil })
+
+	a.NoError(r.SubscriptionRequest("challenge", "verify"))
+	a.Error(r.SubscriptionRequest("challenge", "bad"))
+	a.NoError(r.MessageReceived(&strava.WebhookMessage{ObjectType: "activity", AspectType: "create"}))
+	a.NoError(r.MessageReceived(&strava.WebhookMessage{ObjectType: "activity", AspectType: "update"}))
+	a.Error(r.MessageReceived(&strava.WebhookMessage{ObjectType: "activity", AspectType: "delete"}))
+	a.NoError(r.MessageReceived(deauth))
+	a.NoError(r.MessageReceived(&strava.WebhookMessage{ObjectType: "segment", AspectType: "create"}))
+	a.Error(r.MessageReceived(&strava.WebhookMessage{
+		ObjectType: "activity", AspectType: "update", Updates: map[string]string{"private": "maybe"}}))
+
+	a.Equal(1, created)
+	a.Equal(1, updated)
+	a.Equal(1, deleted)
+	a.Equal(1, deauthorized)
+	a.Equal(1, unhandled)
+}
@@ -0,0 +1,63 @@
+package strava
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bzimmer/activity"
+)
+
+// GearService is the API for gear endpoints
+type GearService service
+
+// Gear returns the gear specified by id
+func (s *GearService) Gear(ctx context.Context, gearID string) (*Gear, error) {
+	if gearID == "" {
+		return nil, errors.New("missing gear id")
+	}
+	uri := fmt.Sprintf("gear/%s", gearID)
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	gear := &Gear{}
+	if err = s.client.do(req, gear); err != nil {
+		return nil, err
+	}
+	return gear, nil
+}
+
+// Usage returns the usage of each piece of gear for the authenticated athlete's activities
+// in the date range, keyed by gear id
+//
+// Activities without gear are not included in the results. Zero values for `before` or
+// `after` leave that end of the date range open.
+func (s *GearService) Usage(ctx context.Context, before, after time.Time) (map[string]*GearUsage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	usage := make(map[string]*GearUsage)
+	acts := s.client.Activity.Activities(ctx, activity.Pagination{}, WithDateRange(before, after))
+	err := ActivitiesIter(acts, func(act *Activity) (bool, error) {
+		if act.GearID == "" {
+			return true, nil
+		}
+		u, ok := usage[act.GearID]
+		if !ok {
+			u = &GearUsage{GearID: act.GearID}
+			usage[act.GearID] = u
+		}
+		u.Count++
+		u.Distance += act.Distance
+		u.MovingTime += act.MovingTime
+		u.ElapsedTime += act.ElapsedTime
+		u.ElevationGain += act.ElevationGain
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
@@ -0,0 +1,111 @@
+package strava_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestGear(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name   string
+		id     string
+		before func(mux *http.ServeMux)
+		after  func(gear *strava.Gear, err error)
+	}{
+		{
+			name: "valid gear",
+			id:   "b1231",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/gear/b1231", func(w http.ResponseWriter, r *http.Request) {
+					http.ServeFile(w, r, "testdata/gear.json")
+				})
+			},
+			after: func(gear *strava.Gear, err error) {
+				a.NoError(err)
+				a.NotNil(gear)
+				a.Equal("BMC", gear.BrandName)
+				a.Equal(float64(388206), gear.Distance.Meters())
+			},
+		},
+		{
+			name:   "missing gear id",
+			before: func(_ *http.ServeMux) {},
+			after: func(gear *strava.Gear, err error) {
+				a.Error(err)
+				a.Nil(gear)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(tt.before)
+			defer svr.Close()
+			tt.after(client.Gear.Gear(context.TODO(), tt.id))
+		})
+	}
+}
+
+func TestGearUsage(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name          string
+		before, after time.Time
+		err           string
+	}{
+		{
+			name:   "valid range",
+			before: time.Now(),
+			after:  time.Now().Add(-time.Hour * 24 * 30),
+		},
+		{
+			name: "open range",
+		},
+		{
+			name:   "invalid range",
+			before: time.Now(),
+			after:  time.Now().Add(time.Hour),
+			err:    "invalid date range",
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.Handle("/athlete/activities", &ManyHandler{
+					Total:    3,
+					Filename: "testdata/activity.json",
+				})
+			})
+			defer svr.Close()
+			usage, err := client.Gear.Usage(context.TODO(), tt.before, tt.after)
+			if tt.err != "" {
+				a.Error(err)
+				a.Nil(usage)
+				a.Contains(err.Error(), tt.err)
+				return
+			}
+			a.NoError(err)
+			a.Len(usage, 1)
+			u := usage["b12345678987654321"]
+			a.NotNil(u)
+			a.Equal(3, u.Count)
+			a.Equal(float64(74794), math.Round(u.Distance.Meters()))
+			a.Equal(float64(13500), u.MovingTime.Seconds())
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package strava
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultMaxBodySize = 64 * 1024
+
+// WebhookOutcome describes how the handler disposed of a webhook request
+type WebhookOutcome string
+
+const (
+	// WebhookAccepted the request was delivered to the subscriber successfully
+	WebhookAccepted WebhookOutcome = "accepted"
+	// WebhookFailed the subscriber returned an error
+	WebhookFailed WebhookOutcome = "failed"
+	// WebhookRejected the request was invalid or failed verification
+	WebhookRejected WebhookOutcome = "rejected"
+	// WebhookIgnored the message was for an owner not in the allow-list
+	WebhookIgnored WebhookOutcome = "ignored"
+	// WebhookDuplicate the message was received previously within the deduplication window
+	WebhookDuplicate WebhookOutcome = "duplicate"
+)
+
+// WebhookResult is passed to the handler's hooks after each request is served
+type WebhookResult struct {
+	Method   string          `json:"method"`
+	Message  *WebhookMessage `json:"message,omitempty"`
+	Outcome  WebhookOutcome  `json:"outcome"`
+	Status   int             `json:"status"`
+	Err      error           `json:"-"`
+	Received time.Time       `json:"received"`
+	Duration time.Duration   `json:"duration"`
+}
+
+// WebhookHook is called after every webhook request is served, typically for logging or metrics
+type WebhookHook func(*WebhookResult)
+
+// WebhookHandlerOption configures the webhook handler
+type WebhookHandlerOption func(*webhookHandler)
+
+// WithVerifyToken validates the subscription request's verify token
+//
+// The comparison is constant-time and occurs before the subscriber is called. An empty
+// token disables verification.
+func WithVerifyToken(token string) WebhookHandlerOption {
+	return func(h *webhookHandler) {
+		h.verify = nil
+		if token != "" {
+			h.verify = []byte(token)
+		}
+	}
+}
+
+// WithMaxBodySize limits the size of the event request body
+func WithMaxBodySize(size int64) WebhookHandlerOption {
+	return func(h *webhookHandler) {
+		if size > 0 {
+			h.maxBodySize = size
+		}
+	}
+}
+
+// WithOwners restricts delivered messages to the athlete ids
+//
+// Messages for other owners are acknowledged but not delivered to the subscriber.
+func WithOwners(ownerIDs ...int) WebhookHandlerOption {
+	return func(h *webhookHandler) {
+		if h.owners == nil {
+			h.owners = make(map[int]struct{})
+		}
+		for _, id := range ownerIDs {
+			h.owners[id] = struct{}{}
+		}
+	}
+}
+
+// WithDeduplication acknowledges but does not deliver a message if a message with the same
+// object id, aspect type and event time was received within the window
+func WithDeduplication(window time.Duration) WebhookHandlerOption {
+	return func(h *webhookHandler) {
+		h.window = window
+	}
+}
+
+// WithWebhookHook adds a hook called after every request is served
+func WithWebhookHook(hook WebhookHook) WebhookHandlerOption {
+	return func(h *webhookHandler) {
+		h.hooks = append(h.hooks, hook)
+	}
+}
+
+type webhookKey struct {
+	objectID   int64
+	aspectType string
+	eventTime  int
+}
+
+type webhookSeen struct {
+	key  webhookKey
+	seen time.Time
+}
+
+type webhookHandler struct {
+	sub         WebhookSubscriber
+	verify      []byte
+	maxBodySize int64
+	owners      map[int]struct{}
+	window      time.Duration
+	hooks       []WebhookHook
+	now         func() time.Time
+
+	mu   sync.Mutex
+	seen map[webhookKey]time.Time
+	// order is the keys in the order they were seen for expiring them from the map
+	order []webhookSeen
+}
+
+// NewWebhookHandler returns a http.Handler for servicing webhook requests
+func NewWebhookHandler(sub WebhookSubscriber, opts ...WebhookHandlerOption) http.Handler {
+	h := &webhookHandler{
+		sub:         sub,
+		maxBodySize: defaultMaxBodySize,
+		now:         time.Now,
+		seen:        make(map[webhookKey]time.Time),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res := &WebhookResult{Method: r.Method, Received: h.now()}
+	switch r.Method {
+	case http.MethodGet:
+		h.subscription(w, r, res)
+	case http.MethodPost:
+		h.event(w, r, res)
+	default:
+		res.Outcome = WebhookRejected
+		res.Status = http.StatusMethodNotAllowed
+		w.WriteHeader(res.Status)
+	}
+	res.Duration = h.now().Sub(res.Received)
+	for _, hook := range h.hooks {
+		hook(res)
+	}
+}
+
+// subscription handles subscription requests from Strava (GET)
+func (h *webhookHandler) subscription(w http.ResponseWriter, r *http.Request, res *WebhookResult) {
+	q := r.URL.Query()
+	verify, challenge := q.Get("hub.verify_token"), q.Get("hub.challenge")
+	switch {
+	case verify == "" || challenge == "":
+		h.reject(w, res, http.StatusBadRequest, errors.New("missing verify token or challenge"))
+		return
+	case h.verify != nil && subtle.ConstantTimeCompare(h.verify, []byte(verify)) != 1:
+		h.reject(w, res, http.StatusForbidden, errors.New("invalid verify token"))
+		return
+	}
+	if h.sub != nil {
+		// if err is not nil the verification failed
+		if err := h.sub.SubscriptionRequest(challenge, verify); err != nil {
+			res.Outcome, res.Status, res.Err = WebhookFailed, http.StatusInternalServerError, err
+			w.WriteHeader(res.Status)
+			return
+		}
+	}
+	h.respond(w, res, WebhookAccepted, map[string]string{"hub.challenge": challenge})
+}
+
+// event receives the webhook callbacks from Strava (POST)
+func (h *webhookHandler) event(w http.ResponseWriter, r *http.Request, res *WebhookResult) {
+	body := http.MaxBytesReader(w, r.Body, h.maxBodySize)
+	m := &WebhookMessage{}
+	if err := json.NewDecoder(body).Decode(m); err != nil {
+		status := http.StatusBadRequest
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		h.reject(w, res, status, err)
+		return
+	}
+	res.Message = m
+	if h.owners != nil {
+		if _, ok := h.owners[m.OwnerID]; !ok {
+			h.respond(w, res, WebhookIgnored, map[string]string{"status": "ok"})
+			return
+		}
+	}
+	key := webhookKey{objectID: m.ObjectID, aspectType: m.AspectType, eventTime: m.EventTime}
+	if !h.observe(key, res.Received) {
+		h.respond(w, res, WebhookDuplicate, map[string]string{"status": "ok"})
+		return
+	}
+	if h.sub != nil {
+		if err := h.sub.MessageReceived(m); err != nil {
+			// allow Strava's retry to be delivered
+			h.forget(key)
+			res.Outcome, res.Status, res.Err = WebhookFailed, http.StatusInternalServerError, err
+			w.WriteHeader(res.Status)
+			return
+		}
+	}
+	h.respond(w, res, WebhookAccepted, map[string]string{"status": "ok"})
+}
+
+// observe records the key returning false if it was seen within the deduplication window
+func (h *webhookHandler) observe(key webhookKey, now time.Time) bool {
+	if h.window <= 0 {
+		return true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var n int
+	for ; n < len(h.order) && now.Sub(h.order[n].seen) > h.window; n++ {
+		// the key may have been forgotten and seen again since
+		if t, ok := h.seen[h.order[n].key]; ok && t.Equal(h.order[n].seen) {
+			delete(h.seen, h.order[n].key)
+		}
+	}
+	h.order = h.order[n:]
+	if _, ok := h.seen[key]; ok {
+		return false
+	}
+	h.seen[key] = now
+	h.order = append(h.order, webhookSeen{key: key, seen: now})
+	return true
+}
+
+func (h *webhookHandler) forget(key webhookKey) {
+	if h.window <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, key)
+}
+
+func (h *webhookHandler) reject(w http.ResponseWriter, res *WebhookResult, status int, err error) {
+	res.Outcome, res.Status, res.Err = WebhookRejected, status, err
+	w.WriteHeader(status)
+}
+
+func (h *webhookHandler) respond(w http.ResponseWriter, res *WebhookResult, outcome WebhookOutcome, body any) {
+	res.Outcome, res.Status = outcome, http.StatusOK
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		res.Err = err
+	}
+}
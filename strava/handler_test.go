@@ -0,0 +1,192 @@
+package strava_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestWebhookHandlerOptions(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	event := func(ownerID int) string {
+		return fmt.Sprintf(`{"aspect_type": "create", "event_time": 1516126040, "object_id": 1360128428,
+			"object_type": "activity", "owner_id": %d, "subscription_id": 120475}`, ownerID)
+	}
+
+	type request struct {
+		method  string
+		target  string
+		body    string
+		status  int
+		outcome strava.WebhookOutcome
+	}
+
+	tests := []struct {
+		name     string
+		opts     []strava.WebhookHandlerOption
+		requests []request
+		messages int
+	}{
+		{
+			name: "missing challenge",
+			requests: []request{
+				{method: http.MethodGet, target: "/webhook?hub.verify_token=bar", status: http.StatusBadRequest, outcome: strava.WebhookRejected},
+				{method: http.MethodGet, target: "/webhook", status: http.StatusBadRequest, outcome: strava.WebhookRejected},
+			},
+		},
+		{
+			name: "verify token",
+			opts: []strava.WebhookHandlerOption{strava.WithVerifyToken("bar")},
+			requests: []request{
+				{method: http.MethodGet, target: "/webhook?hub.verify_token=bar&hub.challenge=baz", status: http.StatusOK, outcome: strava.WebhookAccepted},
+				{method: http.MethodGet, target: "/webhook?hub.verify_token=foo&hub.challenge=baz", status: http.StatusForbidden, outcome: strava.WebhookRejected},
+			},
+		},
+		{
+			name: "empty verify token",
+			opts: []strava.WebhookHandlerOption{strava.WithVerifyToken("")},
+			requests: []request{
+				{method: http.MethodGet, target: "/webhook?hub.verify_token=foo&hub.challenge=baz", status: http.StatusOK, outcome: strava.WebhookAccepted},
+			},
+		},
+		{
+			name: "non-positive body size",
+			opts: []strava.WebhookHandlerOption{strava.WithMaxBodySize(0), strava.WithMaxBodySize(-1)},
+			requests: []request{
+				{method: http.MethodPost, target: "/webhook", body: event(1), status: http.StatusOK, outcome: strava.WebhookAccepted},
+			},
+			messages: 1,
+		},
+		{
+			name: "body size",
+			opts: []strava.WebhookHandlerOption{strava.WithMaxBodySize(32)},
+			requests: []request{
+				{method: http.MethodPost, target: "/webhook", body: event(1), status: http.StatusRequestEntityTooLarge, outcome: strava.WebhookRejected},
+			},
+		},
+		{
+			name: "invalid body",
+			requests: []request{
+				{method: http.MethodPost, target: "/webhook", body: "{", status: http.StatusBadRequest, outcome: strava.WebhookRejected},
+			},
+		},
+		{
+			name: "owners",
+			opts: []strava.WebhookHandlerOption{strava.WithOwners(1, 2)},
+			requests: []request{
+				{method: http.MethodPost, target: "/webhook", body: event(1), status: http.StatusOK, outcome: strava.WebhookAccepted},
+				{method: http.MethodPost, target: "/webhook", body: event(3), status: http.StatusOK, outcome: strava.WebhookIgnored},
+			},
+			messages: 1,
+		},
+		{
+			name: "deduplication",
+			opts: []strava.WebhookHandlerOption{strava.WithDeduplication(time.Minute)},
+			requests: []request{
+				{method: http.MethodPost, target: "/webhook", body: event(1), status: http.StatusOK, outcome: strava.WebhookAccepted},
+				{method: http.MethodPost, target: "/webhook", body: event(1), status: http.StatusOK, outcome: strava.WebhookDuplicate},
+				{method: http.MethodPost, target: "/webhook", body: event(2), status: http.StatusOK, outcome: strava.WebhookDuplicate},
+			},
+			messages: 1,
+		},
+		{
+			name: "no deduplication",
+			requests: []request{
+				{method: http.MethodPost, target: "/webhook", body: event(1), status: http.StatusOK, outcome: strava.WebhookAccepted},
+				{method: http.MethodPost, target: "/webhook", body: event(1), status: http.StatusOK, outcome: strava.WebhookAccepted},
+			},
+			messages: 2,
+		},
+		{
+			name: "method not allowed",
+			requests: []request{
+				{method: http.MethodPut, target: "/webhook", status: http.StatusMethodNotAllowed, outcome: strava.WebhookRejected},
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var results []*strava.WebhookResult
+			var messages int
+			router := strava.NewWebhookRouter()
+			router.OnActivityCreated(func(*strava.ActivityCreated) error { messages++; return nil })
+			opts := append([]strava.WebhookHandlerOption{
+				strava.WithWebhookHook(func(res *strava.WebhookResult) { results = append(results, res) }),
+			}, tt.opts...)
+			handler := strava.NewWebhookHandler(router, opts...)
+			for j, rq := range tt.requests {
+				w := httptest.NewRecorder()
+				req, err := http.NewRequestWithContext(context.TODO(), rq.method, rq.target, strings.NewReader(rq.body))
+				a.NoError(err)
+				handler.ServeHTTP(w, req)
+				a.Equal(rq.status, w.Code)
+				a.Equal(rq.status, results[j].Status)
+				a.Equal(rq.outcome, results[j].Outcome)
+			}
+			a.Len(results, len(tt.requests))
+			a.Equal(tt.messages, messages)
+		})
+	}
+}
+
+func TestWebhookHandlerDeduplicationRetry(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	sub := &TestSubscriber{fail: true}
+	handler := strava.NewWebhookHandler(sub, strava.WithDeduplication(time.Minute))
+	body := `{"aspect_type": "create", "event_time": 1516126040, "object_id": 1360128428, "object_type": "activity"}`
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body)))
+	a.Equal(http.StatusInternalServerError, w.Code)
+
+	// a failed message is not recorded so the retry is delivered
+	sub.fail = false
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body)))
+	a.Equal(http.StatusOK, w.Code)
+	a.NotNil(sub.msg)
+}
+
+func TestWebhookHandlerDeduplicationExpiry(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var outcomes []strava.WebhookOutcome
+	handler := strava.NewWebhookHandler(&TestSubscriber{},
+		strava.WithDeduplication(10*time.Millisecond),
+		strava.WithWebhookHook(func(res *strava.WebhookResult) { outcomes = append(outcomes, res.Outcome) }))
+	event := func(objectID int) string {
+		return fmt.Sprintf(`{"aspect_type": "create", "event_time": 1516126040, "object_id": %d, "object_type": "activity"}`, objectID)
+	}
+	serve := func(body string) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body)))
+		a.Equal(http.StatusOK, w.Code)
+	}
+
+	serve(event(1))
+	serve(event(1))
+	time.Sleep(20 * time.Millisecond)
+	// the first event expired so is delivered again
+	serve(event(2))
+	serve(event(1))
+	serve(event(2))
+	a.Equal([]strava.WebhookOutcome{
+		strava.WebhookAccepted, strava.WebhookDuplicate,
+		strava.WebhookAccepted, strava.WebhookAccepted, strava.WebhookDuplicate,
+	}, outcomes)
+}
@@ -0,0 +1,181 @@
+package strava
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// WebhookJournalEntry is a webhook message recorded in the journal
+type WebhookJournalEntry struct {
+	Received time.Time       `json:"received"`
+	Outcome  WebhookOutcome  `json:"outcome"`
+	Error    string          `json:"error,omitempty"`
+	Message  *WebhookMessage `json:"message"`
+}
+
+// WebhookJournal is an append-only record of every webhook message received
+//
+// Add the journal's Hook to the webhook handler to record messages as they are received.
+// If the handler delivers messages to a Dispatcher the handler's outcome only reflects
+// whether the message was enqueued, so also add the Hook to the Dispatcher with
+// WithDispatchHook: a later processing outcome, accepted or failed, for the same message
+// supersedes the earlier one.
+type WebhookJournal struct {
+	mu   sync.Mutex
+	path string
+	fp   *os.File
+	enc  *json.Encoder
+	err  error
+}
+
+// NewWebhookJournal opens (or creates) the journal at `path` for appending
+func NewWebhookJournal(path string) (*WebhookJournal, error) {
+	fp, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookJournal{path: path, fp: fp, enc: json.NewEncoder(fp)}, nil
+}
+
+// Record appends the result of a webhook request to the journal
+//
+// Results without a message, such as subscription requests, are not recorded.
+func (j *WebhookJournal) Record(res *WebhookResult) error {
+	if res.Message == nil {
+		return nil
+	}
+	entry := &WebhookJournalEntry{Received: res.Received, Outcome: res.Outcome, Message: res.Message}
+	if res.Err != nil {
+		entry.Error = res.Err.Error()
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.enc.Encode(entry); err != nil {
+		return err
+	}
+	return j.fp.Sync()
+}
+
+// Hook returns a WebhookHook recording every result in the journal
+//
+// The hook cannot return an error so the first failure to record is available from Err.
+func (j *WebhookJournal) Hook() WebhookHook {
+	return func(res *WebhookResult) {
+		if err := j.Record(res); err != nil {
+			j.mu.Lock()
+			defer j.mu.Unlock()
+			if j.err == nil {
+				j.err = err
+			}
+		}
+	}
+}
+
+// Err returns the first error encountered by the journal's hook
+func (j *WebhookJournal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Entries returns the entries received in the range [from, to)
+//
+// A zero `to` includes all entries received after `from`. If a message was recorded more
+// than once the entry has the time it was first received and the latest outcome of
+// processing it. The outcomes of redeliveries which were not processed, such as
+// WebhookDuplicate, do not supersede an earlier outcome so a failed message is still
+// replayed.
+func (j *WebhookJournal) Entries(from, to time.Time) ([]*WebhookJournalEntry, error) {
+	fp, err := os.Open(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	var all []*WebhookJournalEntry
+	messages := make(map[string]*WebhookJournalEntry)
+	scanner := bufio.NewScanner(fp)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := &WebhookJournalEntry{}
+		if err = json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("corrupt journal: %w", err)
+		}
+		// the message's encoding identifies it across the entries recording its outcomes
+		b, err := json.Marshal(entry.Message)
+		if err != nil {
+			return nil, err
+		}
+		if prev, ok := messages[string(b)]; ok {
+			if processed(entry.Outcome) {
+				prev.Outcome, prev.Error = entry.Outcome, entry.Error
+			}
+			continue
+		}
+		messages[string(b)] = entry
+		all = append(all, entry)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	var entries []*WebhookJournalEntry
+	for _, entry := range all {
+		if entry.Received.Before(from) || (!to.IsZero() && !entry.Received.Before(to)) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Replay re-delivers the messages received in the range [from, to) to the subscriber
+//
+// If any outcomes are specified only entries with those outcomes are replayed, eg
+// WebhookFailed to redeliver only the messages the subscriber failed to process.
+// Replay stops at the first error and returns the number of messages delivered.
+func (j *WebhookJournal) Replay(
+	ctx context.Context, sub WebhookSubscriber, from, to time.Time, outcomes ...WebhookOutcome) (int, error) {
+	entries, err := j.Entries(from, to)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for _, entry := range entries {
+		if err = ctx.Err(); err != nil {
+			return n, err
+		}
+		if len(outcomes) > 0 && !containsOutcome(outcomes, entry.Outcome) {
+			continue
+		}
+		if err = sub.MessageReceived(entry.Message); err != nil {
+			return n, fmt.Errorf("replay of message received at %s: %w", entry.Received.Format(time.RFC3339), err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Close the journal
+func (j *WebhookJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.fp.Close()
+}
+
+func containsOutcome(outcomes []WebhookOutcome, outcome WebhookOutcome) bool {
+	for _, o := range outcomes {
+		if o == outcome {
+			return true
+		}
+	}
+	return false
+}
+
+// processed returns true if the outcome is the result of processing the message
+func processed(outcome WebhookOutcome) bool {
+	return outcome == WebhookAccepted || outcome == WebhookFailed
+}
@@ -0,0 +1,181 @@
+package strava_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestWebhookJournal(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "webhooks.jsonl")
+	journal, err := strava.NewWebhookJournal(path)
+	a.NoError(err)
+	defer journal.Close()
+
+	router := strava.NewWebhookRouter()
+	router.OnActivityDeleted(func(*strava.ActivityDeleted) error { return errors.New("failed") })
+	router.OnAthleteDeauthorized(func(*strava.AthleteDeauthorized) error { return nil })
+	svr := httptest.NewServer(strava.NewWebhookHandler(router, strava.WithWebhookHook(journal.Hook())))
+	defer svr.Close()
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Second)
+	sim := strava.NewWebhookSimulator(svr.URL, 88)
+	a.NoError(sim.Subscribe(ctx, "verify"))
+	a.NoError(sim.ActivityCreated(ctx, 1))
+	a.NoError(sim.ActivityUpdated(ctx, 1, map[string]string{"title": "Morning Ride"}))
+	a.Error(sim.ActivityDeleted(ctx, 1))
+	a.NoError(sim.AthleteDeauthorized(ctx))
+	a.NoError(journal.Err())
+
+	// subscription requests are not recorded
+	entries, err := journal.Entries(start, time.Time{})
+	a.NoError(err)
+	a.Len(entries, 4)
+	a.Equal(strava.WebhookFailed, entries[2].Outcome)
+	a.Equal("failed", entries[2].Error)
+	a.Equal("delete", entries[2].Message.AspectType)
+
+	entries, err = journal.Entries(time.Time{}, start)
+	a.NoError(err)
+	a.Empty(entries)
+
+	sub := &TestSubscriber{}
+	n, err := journal.Replay(ctx, sub, start, time.Time{}, strava.WebhookFailed)
+	a.NoError(err)
+	a.Equal(1, n)
+	a.Equal(int64(1), sub.msg.ObjectID)
+	a.Equal("delete", sub.msg.AspectType)
+
+	n, err = journal.Replay(ctx, sub, start, time.Time{})
+	a.NoError(err)
+	a.Equal(4, n)
+
+	sub.fail = true
+	n, err = journal.Replay(ctx, sub, start, time.Time{})
+	a.Error(err)
+	a.Equal(0, n)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	n, err = journal.Replay(ctx, sub, start, time.Time{})
+	a.ErrorIs(err, context.Canceled)
+	a.Equal(0, n)
+
+	a.NoError(os.WriteFile(path, []byte("not json\n"), 0o600))
+	entries, err = journal.Entries(start, time.Time{})
+	a.Error(err)
+	a.Nil(entries)
+}
+
+func TestWebhookJournalDispatcher(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	journal, err := strava.NewWebhookJournal(filepath.Join(t.TempDir(), "webhooks.jsonl"))
+	a.NoError(err)
+	defer journal.Close()
+
+	router := strava.NewWebhookRouter()
+	router.OnActivityCreated(func(*strava.ActivityCreated) error { return nil })
+	router.OnActivityDeleted(func(*strava.ActivityDeleted) error { return errors.New("failed") })
+	var dead atomic.Int32
+	d := strava.NewDispatcher(router,
+		strava.WithRetries(0),
+		strava.WithDispatchHook(journal.Hook()),
+		strava.WithDeadLetter(func(*strava.WebhookMessage, error) { dead.Add(1) }))
+	svr := httptest.NewServer(strava.NewWebhookHandler(d, strava.WithWebhookHook(journal.Hook())))
+	defer svr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+
+	start := time.Now().Add(-time.Second)
+	sim := strava.NewWebhookSimulator(svr.URL, 88)
+	a.NoError(sim.ActivityCreated(ctx, 1))
+	// the handler acknowledges the message since it was enqueued
+	a.NoError(sim.ActivityDeleted(ctx, 2))
+	a.Eventually(func() bool { return dead.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+	a.NoError(<-done)
+	a.NoError(journal.Err())
+
+	entries, err := journal.Entries(start, time.Time{})
+	a.NoError(err)
+	a.Len(entries, 2)
+	a.Equal(strava.WebhookAccepted, entries[0].Outcome)
+	a.Equal(strava.WebhookFailed, entries[1].Outcome)
+	a.Equal("failed", entries[1].Error)
+
+	sub := &TestSubscriber{}
+	n, err := journal.Replay(context.Background(), sub, start, time.Time{}, strava.WebhookFailed)
+	a.NoError(err)
+	a.Equal(1, n)
+	a.Equal(int64(2), sub.msg.ObjectID)
+}
+
+func TestWebhookJournalOutcomes(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name     string
+		outcomes []strava.WebhookOutcome
+		outcome  strava.WebhookOutcome
+		replayed int
+	}{
+		{
+			name:     "failed then duplicate",
+			outcomes: []strava.WebhookOutcome{strava.WebhookAccepted, strava.WebhookFailed, strava.WebhookDuplicate},
+			outcome:  strava.WebhookFailed,
+			replayed: 1,
+		},
+		{
+			name:     "failed then ignored",
+			outcomes: []strava.WebhookOutcome{strava.WebhookFailed, strava.WebhookIgnored},
+			outcome:  strava.WebhookFailed,
+			replayed: 1,
+		},
+		{
+			name:     "failed then accepted",
+			outcomes: []strava.WebhookOutcome{strava.WebhookFailed, strava.WebhookDuplicate, strava.WebhookAccepted},
+			outcome:  strava.WebhookAccepted,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			journal, err := strava.NewWebhookJournal(filepath.Join(t.TempDir(), "webhooks.jsonl"))
+			a.NoError(err)
+			defer journal.Close()
+
+			start := time.Now().Add(-time.Second)
+			msg := &strava.WebhookMessage{ObjectType: "activity", AspectType: "create", ObjectID: 7, OwnerID: 88}
+			for _, outcome := range tt.outcomes {
+				a.NoError(journal.Record(&strava.WebhookResult{Received: time.Now(), Outcome: outcome, Message: msg}))
+			}
+			entries, err := journal.Entries(start, time.Time{})
+			a.NoError(err)
+			a.Len(entries, 1)
+			a.Equal(tt.outcome, entries[0].Outcome)
+
+			n, err := journal.Replay(context.Background(), &TestSubscriber{}, start, time.Time{}, strava.WebhookFailed)
+			a.NoError(err)
+			a.Equal(tt.replayed, n)
+		})
+	}
+}
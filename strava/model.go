@@ -18,6 +18,7 @@ type Error struct {
 
 // Fault contains errors
 type Fault struct { //nolint:errname // convention
+	activity.FaultStatus
 	Code    int      `json:"code"`
 	Message string   `json:"message"`
 	Errors  []*Error `json:"errors"`
@@ -91,6 +92,22 @@ type Gear struct {
 	ResourceState int         `json:"resource_state"`
 	Distance      unit.Length `json:"distance" units:"m"`
 	AthleteID     int         `json:"athlete_id"`
+	BrandName     string      `json:"brand_name,omitempty"`
+	ModelName     string      `json:"model_name,omitempty"`
+	FrameType     int         `json:"frame_type,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	Nickname      string      `json:"nickname,omitempty"`
+	Retired       bool        `json:"retired"`
+}
+
+// GearUsage summarizes the activities recorded with a piece of gear
+type GearUsage struct {
+	GearID        string        `json:"gear_id"`
+	Count         int           `json:"count"`
+	Distance      unit.Length   `json:"distance" units:"m"`
+	MovingTime    unit.Duration `json:"moving_time" units:"s"`
+	ElapsedTime   unit.Duration `json:"elapsed_time" units:"s"`
+	ElevationGain unit.Length   `json:"elevation_gain" units:"m"`
 }
 
 // Totals for stats
@@ -141,6 +158,30 @@ type Club struct {
 	Verified        bool   `json:"verified"`
 }
 
+// ClubAthlete is an athlete as represented as a member of a club
+type ClubAthlete struct {
+	ResourceState int    `json:"resource_state"`
+	Firstname     string `json:"firstname"`
+	Lastname      string `json:"lastname"`
+	Membership    string `json:"membership"`
+	Admin         bool   `json:"admin"`
+	Owner         bool   `json:"owner"`
+}
+
+// ClubActivity is a summary of an activity by a member of a club
+type ClubActivity struct {
+	ResourceState int           `json:"resource_state"`
+	Athlete       *ClubAthlete  `json:"athlete"`
+	Name          string        `json:"name"`
+	Distance      unit.Length   `json:"distance" units:"m"`
+	MovingTime    unit.Duration `json:"moving_time" units:"s"`
+	ElapsedTime   unit.Duration `json:"elapsed_time" units:"s"`
+	ElevationGain unit.Length   `json:"total_elevation_gain" units:"m"`
+	Type          string        `json:"type"`
+	SportType     string        `json:"sport_type"`
+	WorkoutType   int           `json:"workout_type"`
+}
+
 // Athlete represents a Strava athlete
 type Athlete struct {
 	ID                    int       `json:"id"`
@@ -173,6 +214,37 @@ type Athlete struct {
 	Shoes                 []*Gear   `json:"shoes"`
 }
 
+// UpdatableAthlete represents an athlete with updatable attributes
+type UpdatableAthlete struct {
+	Weight unit.Mass `json:"weight" units:"kg"`
+}
+
+// ZoneRange is a zone bounded by min and max, a max of -1 is unbounded
+type ZoneRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// ZoneRanges are the ordered zones for a metric
+type ZoneRanges []*ZoneRange
+
+// HeartRateZoneRanges are the heart rate zones of an athlete
+type HeartRateZoneRanges struct {
+	CustomZones bool       `json:"custom_zones"`
+	Zones       ZoneRanges `json:"zones"`
+}
+
+// PowerZoneRanges are the power zones of an athlete
+type PowerZoneRanges struct {
+	Zones ZoneRanges `json:"zones"`
+}
+
+// AthleteZones are the heart rate and power zones of an athlete
+type AthleteZones struct {
+	HeartRate *HeartRateZoneRanges `json:"heart_rate,omitempty"`
+	Power     *PowerZoneRanges     `json:"power,omitempty"`
+}
+
 // Map of the activity or route
 type Map struct {
 	ID              string `json:"id"`
@@ -211,6 +283,46 @@ type Lap struct {
 	Split              int           `json:"split"`
 }
 
+// ZoneType of an activity zone distribution
+type ZoneType string
+
+const (
+	// ZoneTypeHeartRate is a heart rate zone distribution
+	ZoneTypeHeartRate ZoneType = "heartrate"
+	// ZoneTypePower is a power zone distribution
+	ZoneTypePower ZoneType = "power"
+)
+
+// TimedZoneRange is the time spent in a zone bounded by min and max
+type TimedZoneRange struct {
+	Min  float64       `json:"min"`
+	Max  float64       `json:"max"`
+	Time unit.Duration `json:"time" units:"s"`
+}
+
+// ActivityZone is the distribution of time spent in heart rate or power zones for an activity
+type ActivityZone struct {
+	Type                ZoneType          `json:"type"`
+	Score               int               `json:"score"`
+	Points              int               `json:"points"`
+	Max                 int               `json:"max"`
+	SensorBased         bool              `json:"sensor_based"`
+	CustomZones         bool              `json:"custom_zones"`
+	ResourceState       int               `json:"resource_state"`
+	DistributionBuckets []*TimedZoneRange `json:"distribution_buckets"`
+}
+
+// Comment on an activity
+type Comment struct {
+	ID            int64     `json:"id"`
+	ActivityID    int64     `json:"activity_id"`
+	PostID        int64     `json:"post_id"`
+	ResourceState int       `json:"resource_state"`
+	Text          string    `json:"text"`
+	CreatedAt     time.Time `json:"created_at"`
+	Athlete       *Athlete  `json:"athlete"`
+}
+
 // PREffort for the segment
 type PREffort struct {
 	Distance       unit.Length   `json:"distance" units:"m"`
@@ -364,6 +476,18 @@ type UpdatableActivity struct {
 	GearID      *string `json:"gear_id,omitempty"`
 }
 
+// CreatableActivity represents a manually created activity without a data file
+type CreatableActivity struct {
+	Name           string        `json:"name"`
+	SportType      string        `json:"sport_type"`
+	StartDateLocal time.Time     `json:"start_date_local"`
+	ElapsedTime    unit.Duration `json:"elapsed_time" units:"s"`
+	Distance       unit.Length   `json:"distance" units:"m"`
+	Description    string        `json:"description"`
+	Trainer        bool          `json:"trainer"`
+	Commute        bool          `json:"commute"`
+}
+
 // Activity represents an activity
 type Activity struct {
 	ID                       int64                  `json:"id"`
@@ -410,6 +534,8 @@ type Activity struct {
 	Kilojoules               float64                `json:"kilojoules"`
 	DeviceWatts              bool                   `json:"device_watts"`
 	HasHeartrate             bool                   `json:"has_heartrate"`
+	AverageHeartrate         float64                `json:"average_heartrate"`
+	MaxHeartrate             float64                `json:"max_heartrate"`
 	MaxWatts                 int                    `json:"max_watts"`
 	ElevationHigh            unit.Length            `json:"elev_high" units:"m"`
 	ElevationLow             unit.Length            `json:"elev_low" units:"m"`
@@ -455,6 +581,7 @@ type Route struct {
 	ID                  int64         `json:"id"`
 	Map                 *Map          `json:"map"`
 	Timestamp           int           `json:"timestamp"`
+	Streams             *Streams      `json:"streams,omitempty"`
 }
 
 type TrainingDate struct {
@@ -507,6 +634,18 @@ func (u *Upload) Done() bool {
 	return u.ActivityID > 0 || u.Error != ""
 }
 
+// Outcome classifies the outcome of the upload
+func (u *Upload) Outcome() *activity.UploadOutcome {
+	switch {
+	case u.Error != "":
+		return activity.FailedOutcome(u.Error)
+	case u.ActivityID > 0:
+		return &activity.UploadOutcome{Status: activity.UploadCreated, ActivityID: u.ActivityID, Message: u.Status}
+	default:
+		return &activity.UploadOutcome{Status: activity.UploadPending, Message: u.Status}
+	}
+}
+
 // UploadResult is the result of polling for upload status
 type UploadResult struct {
 	Upload *Upload `json:"upload"`
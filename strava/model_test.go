@@ -1,10 +1,15 @@
 package strava_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bzimmer/activity"
 	"github.com/bzimmer/activity/strava"
 )
 
@@ -19,3 +24,52 @@ func TestFault(t *testing.T) {
 	a.Error(err)
 	a.Equal("foo", err.Error())
 }
+
+func TestFaultErrors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		err        error
+		wait       time.Duration
+	}{
+		{name: "not found", status: http.StatusNotFound, err: activity.ErrNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized, err: activity.ErrUnauthorized},
+		{name: "rate limited", status: http.StatusTooManyRequests, retryAfter: "30", err: activity.ErrRateLimited, wait: 30 * time.Second},
+		{name: "invalid", status: http.StatusBadRequest, err: activity.ErrInvalidRequest},
+		{name: "server error", status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.HandleFunc("/athlete", func(w http.ResponseWriter, _ *http.Request) {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(`{"message":"failed","errors":[]}`))
+				})
+			})
+			defer svr.Close()
+			ath, err := client.Athlete.Athlete(context.Background())
+			a.Nil(ath)
+			a.Error(err)
+			var fault *strava.Fault
+			a.True(errors.As(err, &fault))
+			a.Equal("failed", fault.Message)
+			a.Equal(tt.status, fault.HTTPStatus())
+			if tt.err != nil {
+				a.ErrorIs(err, tt.err)
+			}
+			a.False(errors.Is(err, activity.ErrExceededIterations))
+			wait, ok := activity.RetryAfter(err)
+			a.Equal(tt.wait, wait)
+			a.Equal(tt.wait > 0, ok)
+		})
+	}
+}
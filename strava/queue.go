@@ -0,0 +1,278 @@
+package strava
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrQueueFull is returned when a message cannot be enqueued because the queue is at capacity
+var ErrQueueFull = errors.New("queue full")
+
+// WebhookEnvelope wraps a webhook message awaiting processing
+type WebhookEnvelope struct {
+	// ID is unique to the queue
+	ID uint64 `json:"id"`
+	// Attempts is the number of processing attempts
+	Attempts int `json:"attempts"`
+	// Message is the webhook message
+	Message *WebhookMessage `json:"message"`
+}
+
+// WebhookQueue is a bounded queue of webhook messages awaiting processing
+type WebhookQueue interface {
+	// Push enqueues the message without blocking, returning ErrQueueFull if at capacity
+	Push(msg *WebhookMessage) error
+	// Pop blocks until a message is available or the context is done
+	Pop(ctx context.Context) (*WebhookEnvelope, error)
+	// Ack marks the message as complete, either processed or dead-lettered
+	Ack(env *WebhookEnvelope) error
+	// Requeue returns a popped but not acknowledged message to the queue
+	Requeue(env *WebhookEnvelope) error
+}
+
+// MemoryQueue is a WebhookQueue held in memory, queued messages are lost on exit
+type MemoryQueue struct {
+	id       atomic.Uint64
+	messages chan *WebhookEnvelope
+}
+
+// NewMemoryQueue returns a new in-memory queue with the capacity
+func NewMemoryQueue(capacity int) *MemoryQueue {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &MemoryQueue{messages: make(chan *WebhookEnvelope, capacity)}
+}
+
+// Push enqueues the message without blocking, returning ErrQueueFull if at capacity
+func (q *MemoryQueue) Push(msg *WebhookMessage) error {
+	return q.push(&WebhookEnvelope{ID: q.id.Add(1), Message: msg})
+}
+
+func (q *MemoryQueue) push(env *WebhookEnvelope) error {
+	select {
+	case q.messages <- env:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Pop blocks until a message is available or the context is done
+func (q *MemoryQueue) Pop(ctx context.Context) (*WebhookEnvelope, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case env := <-q.messages:
+		return env, nil
+	}
+}
+
+// Ack is a no-op for an in-memory queue
+func (q *MemoryQueue) Ack(_ *WebhookEnvelope) error {
+	return nil
+}
+
+// Requeue returns the message to the queue, returning ErrQueueFull if at capacity
+func (q *MemoryQueue) Requeue(env *WebhookEnvelope) error {
+	return q.push(env)
+}
+
+// Len returns the number of queued messages
+func (q *MemoryQueue) Len() int {
+	return len(q.messages)
+}
+
+type journalOp string
+
+const (
+	journalPush journalOp = "push"
+	journalAck  journalOp = "ack"
+)
+
+type journalEntry struct {
+	Op      journalOp       `json:"op"`
+	ID      uint64          `json:"id"`
+	Message *WebhookMessage `json:"message,omitempty"`
+}
+
+// FileQueue is a WebhookQueue backed by an append-only journal file
+//
+// Every enqueued message is written to the journal before it is queued and every
+// acknowledgement is recorded. Messages not acknowledged before exit, including those
+// in-flight, are requeued when the journal is reopened.
+type FileQueue struct {
+	mu    sync.Mutex
+	fp    *os.File
+	enc   *json.Encoder
+	queue *MemoryQueue
+}
+
+// NewFileQueue opens (or creates) the journal at `path` and requeues any pending messages
+func NewFileQueue(path string, capacity int) (*FileQueue, error) {
+	pending, last, err := readJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > capacity {
+		capacity = len(pending)
+	}
+	if err = compactJournal(path, pending); err != nil {
+		return nil, err
+	}
+	fp, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	q := &FileQueue{fp: fp, enc: json.NewEncoder(fp), queue: NewMemoryQueue(capacity)}
+	q.queue.id.Store(last)
+	for _, env := range pending {
+		if err = q.queue.push(env); err != nil {
+			return nil, errors.Join(err, fp.Close())
+		}
+	}
+	return q, nil
+}
+
+// compactJournal replaces the journal with one containing only the pending messages
+//
+// The compacted journal is written and synced to a temporary file which is then renamed
+// over the journal so a crash at any point leaves either the old or the new journal intact.
+func compactJournal(path string, pending []*WebhookEnvelope) error {
+	fp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	tmp := fp.Name()
+	err = func() error {
+		enc := json.NewEncoder(fp)
+		for _, env := range pending {
+			if err := enc.Encode(&journalEntry{Op: journalPush, ID: env.ID, Message: env.Message}); err != nil {
+				return err
+			}
+		}
+		return fp.Sync()
+	}()
+	if err = errors.Join(err, fp.Close()); err != nil {
+		return errors.Join(err, os.Remove(tmp))
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return errors.Join(err, os.Remove(tmp))
+	}
+	// sync the directory so the rename itself is durable
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	return errors.Join(dir.Sync(), dir.Close())
+}
+
+func readJournal(path string) ([]*WebhookEnvelope, uint64, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	defer fp.Close()
+	var last uint64
+	pending := make(map[uint64]*WebhookEnvelope)
+	scanner := bufio.NewScanner(fp)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var torn error
+	for scanner.Scan() {
+		if torn != nil {
+			// only the last line can be incomplete, from a crash while appending
+			return nil, 0, fmt.Errorf("corrupt journal: %w", torn)
+		}
+		var entry journalEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// the line is skipped if it is the last and dropped when the journal is compacted
+			torn = err
+			continue
+		}
+		switch entry.Op {
+		case journalPush:
+			pending[entry.ID] = &WebhookEnvelope{ID: entry.ID, Message: entry.Message}
+		case journalAck:
+			delete(pending, entry.ID)
+		default:
+			return nil, 0, fmt.Errorf("corrupt journal: unknown op '%s'", entry.Op)
+		}
+		if entry.ID > last {
+			last = entry.ID
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	envs := make([]*WebhookEnvelope, 0, len(pending))
+	for _, env := range pending {
+		envs = append(envs, env)
+	}
+	sort.Slice(envs, func(i, j int) bool { return envs[i].ID < envs[j].ID })
+	return envs, last, nil
+}
+
+// Push records the message in the journal and enqueues it, returning ErrQueueFull if at capacity
+func (q *FileQueue) Push(msg *WebhookMessage) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	// pushes are serialized by the lock and pops only free capacity so the check is safe
+	if q.queue.Len() >= cap(q.queue.messages) {
+		return ErrQueueFull
+	}
+	env := &WebhookEnvelope{ID: q.queue.id.Add(1), Message: msg}
+	if err := q.write(&journalEntry{Op: journalPush, ID: env.ID, Message: msg}); err != nil {
+		return err
+	}
+	return q.queue.push(env)
+}
+
+// Pop blocks until a message is available or the context is done
+func (q *FileQueue) Pop(ctx context.Context) (*WebhookEnvelope, error) {
+	return q.queue.Pop(ctx)
+}
+
+// Ack records the completion of the message in the journal
+func (q *FileQueue) Ack(env *WebhookEnvelope) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.write(&journalEntry{Op: journalAck, ID: env.ID})
+}
+
+// Requeue returns the message to the queue
+//
+// The message remains pending in the journal so if the queue is at capacity it is
+// redelivered when the journal is reopened.
+func (q *FileQueue) Requeue(env *WebhookEnvelope) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.queue.push(env); err != nil && !errors.Is(err, ErrQueueFull) {
+		return err
+	}
+	return nil
+}
+
+// Close the journal
+func (q *FileQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.fp.Close()
+}
+
+func (q *FileQueue) write(entry *journalEntry) error {
+	if err := q.enc.Encode(entry); err != nil {
+		return err
+	}
+	return q.fp.Sync()
+}
@@ -0,0 +1,104 @@
+package strava_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestMemoryQueue(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	q := strava.NewMemoryQueue(2)
+	a.NoError(q.Push(&strava.WebhookMessage{ObjectID: 1}))
+	a.NoError(q.Push(&strava.WebhookMessage{ObjectID: 2}))
+	a.ErrorIs(q.Push(&strava.WebhookMessage{ObjectID: 3}), strava.ErrQueueFull)
+	a.Equal(2, q.Len())
+
+	env, err := q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(uint64(1), env.ID)
+	a.Equal(int64(1), env.Message.ObjectID)
+	a.NoError(q.Ack(env))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	env, err = q.Pop(ctx)
+	a.NoError(err)
+	a.Equal(int64(2), env.Message.ObjectID)
+	env, err = q.Pop(ctx)
+	a.ErrorIs(err, context.DeadlineExceeded)
+	a.Nil(env)
+}
+
+func TestFileQueue(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "webhooks.journal")
+	q, err := strava.NewFileQueue(path, 2)
+	a.NoError(err)
+	a.NoError(q.Push(&strava.WebhookMessage{ObjectID: 1}))
+	a.NoError(q.Push(&strava.WebhookMessage{ObjectID: 2}))
+	a.ErrorIs(q.Push(&strava.WebhookMessage{ObjectID: 3}), strava.ErrQueueFull)
+
+	env, err := q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(int64(1), env.Message.ObjectID)
+	a.NoError(q.Ack(env))
+	// pop but do not ack to simulate an in-flight message at exit
+	env, err = q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(int64(2), env.Message.ObjectID)
+	a.NoError(q.Close())
+
+	q, err = strava.NewFileQueue(path, 2)
+	a.NoError(err)
+	env, err = q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(int64(2), env.Message.ObjectID)
+	a.Equal(uint64(2), env.ID)
+	a.NoError(q.Push(&strava.WebhookMessage{ObjectID: 4}))
+	env, err = q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(uint64(3), env.ID)
+	a.NoError(q.Close())
+
+	// a torn final line from a crash while appending is dropped
+	fp, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	a.NoError(err)
+	_, err = fp.WriteString(`{"op":"push","id":4,"mess`)
+	a.NoError(err)
+	a.NoError(fp.Close())
+	q, err = strava.NewFileQueue(path, 2)
+	a.NoError(err)
+	env, err = q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(uint64(2), env.ID)
+	a.NoError(q.Requeue(env))
+	env, err = q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(uint64(3), env.ID)
+	env, err = q.Pop(context.Background())
+	a.NoError(err)
+	a.Equal(uint64(2), env.ID)
+	a.NoError(q.Close())
+	b, err := os.ReadFile(path)
+	a.NoError(err)
+	a.NotContains(string(b), `"id":4`)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	a.NoError(err)
+	a.Len(entries, 1)
+
+	a.NoError(os.WriteFile(path, []byte("not json\n{}\n"), 0o600))
+	q, err = strava.NewFileQueue(path, 2)
+	a.Error(err)
+	a.Nil(q)
+}
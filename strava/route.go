@@ -2,6 +2,7 @@ package strava
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -67,3 +68,60 @@ func (s *RouteService) Route(ctx context.Context, routeID int64) (*Route, error)
 	}
 	return rte, nil
 }
+
+// Streams returns the route's data streams
+//
+// Strava returns all available streams for a route (latlng, distance, and altitude)
+func (s *RouteService) Streams(ctx context.Context, routeID int64) (*Streams, error) {
+	uri := fmt.Sprintf("routes/%d/streams", routeID)
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []map[string]json.RawMessage
+	if err = s.client.do(req, &res); err != nil {
+		return nil, err
+	}
+	// the route streams are a list of typed streams rather than an object keyed by type
+	keyed := make(map[string]map[string]json.RawMessage, len(res))
+	for _, stream := range res {
+		var typ string
+		if err = json.Unmarshal(stream["type"], &typ); err != nil {
+			return nil, err
+		}
+		delete(stream, "type")
+		keyed[typ] = stream
+	}
+	b, err := json.Marshal(keyed)
+	if err != nil {
+		return nil, err
+	}
+	sts := &Streams{}
+	if err = json.Unmarshal(b, sts); err != nil {
+		return nil, err
+	}
+	return sts, nil
+}
+
+// Export exports a route in the GPX format
+func (s *RouteService) Export(ctx context.Context, routeID int64) (*activity.Export, error) {
+	return s.ExportFormat(ctx, routeID, activity.FormatGPX)
+}
+
+// ExportFormat exports a route in either the GPX or TCX format
+func (s *RouteService) ExportFormat(
+	ctx context.Context, routeID int64, format activity.Format) (*activity.Export, error) {
+	if format != activity.FormatGPX && format != activity.FormatTCX {
+		return nil, fmt.Errorf("unsupported format '%s'", format)
+	}
+	uri := fmt.Sprintf("routes/%d/export_%s", routeID, format)
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	file, err := activity.Download(s.client.client, req, fmt.Sprintf("%d.%s", routeID, format), format, newFault)
+	if err != nil {
+		return nil, err
+	}
+	return &activity.Export{File: file, ID: routeID}, nil
+}
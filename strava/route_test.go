@@ -114,3 +114,127 @@ func TestRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteStreams(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name   string
+		before func(mux *http.ServeMux)
+		after  func(streams *strava.Streams, err error)
+	}{
+		{
+			name: "valid streams",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/routes/26587226/streams", func(w http.ResponseWriter, r *http.Request) {
+					http.ServeFile(w, r, "testdata/route_streams.json")
+				})
+			},
+			after: func(streams *strava.Streams, err error) {
+				a.NoError(err)
+				a.NotNil(streams)
+				a.Len(streams.LatLng.Data, 4)
+				a.Len(streams.Distance.Data, 4)
+				a.Len(streams.Elevation.Data, 4)
+				a.Equal("high", streams.LatLng.Resolution)
+				a.Equal(1660.3, streams.Elevation.Data[3].Meters())
+			},
+		},
+		{
+			name: "route not found",
+			before: func(mux *http.ServeMux) {
+				mux.HandleFunc("/routes/26587226/streams", func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				})
+			},
+			after: func(streams *strava.Streams, err error) {
+				a.Error(err)
+				a.Nil(streams)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(tt.before)
+			defer svr.Close()
+			tt.after(client.Route.Streams(context.TODO(), 26587226))
+		})
+	}
+}
+
+func TestRouteExport(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name   string
+		format activity.Format
+		err    bool
+		file   string
+	}{
+		{
+			name:   "gpx",
+			format: activity.FormatGPX,
+			file:   "Deer_Park.gpx",
+		},
+		{
+			name:   "tcx",
+			format: activity.FormatTCX,
+			file:   "26587226.tcx",
+		},
+		{
+			name:   "fit",
+			format: activity.FormatFIT,
+			err:    true,
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.HandleFunc("/routes/26587226/export_gpx", func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Disposition", `attachment; filename="Deer_Park.gpx"`)
+					http.ServeFile(w, r, "testdata/example.gpx")
+				})
+				mux.HandleFunc("/routes/26587226/export_tcx", func(w http.ResponseWriter, _ *http.Request) {
+					_, err := w.Write([]byte("<TrainingCenterDatabase/>"))
+					a.NoError(err)
+				})
+			})
+			defer svr.Close()
+			exp, err := client.Route.ExportFormat(context.TODO(), 26587226, tt.format)
+			if tt.err {
+				a.Error(err)
+				a.Nil(exp)
+				return
+			}
+			a.NoError(err)
+			a.NotNil(exp)
+			a.Equal(int64(26587226), exp.ID)
+			a.Equal(tt.format, exp.Format)
+			a.Equal(tt.file, exp.Name)
+		})
+	}
+}
+
+func TestRouteExporter(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	client, svr := newClientMust(func(mux *http.ServeMux) {
+		mux.HandleFunc("/routes/26587226/export_gpx", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	})
+	defer svr.Close()
+	exp, err := client.RouteExporter().Export(context.TODO(), 26587226)
+	a.Error(err)
+	a.Nil(exp)
+	var fault *strava.Fault
+	a.ErrorAs(err, &fault)
+	a.Equal(http.StatusNotFound, fault.Code)
+}
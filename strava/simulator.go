@@ -0,0 +1,91 @@
+package strava
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookSimulator posts synthetic Strava webhook requests to a callback URL for local development
+type WebhookSimulator struct {
+	// Client is the http client used to post events
+	Client *http.Client
+	// CallbackURL is the url of the webhook handler
+	CallbackURL string
+	// OwnerID is the athlete id of the synthetic events
+	OwnerID int
+	// SubscriptionID is the subscription id of the synthetic events
+	SubscriptionID int64
+}
+
+// NewWebhookSimulator returns a simulator for the callback URL and athlete
+func NewWebhookSimulator(callbackURL string, ownerID int) *WebhookSimulator {
+	return &WebhookSimulator{
+		Client:         http.DefaultClient,
+		CallbackURL:    callbackURL,
+		OwnerID:        ownerID,
+		SubscriptionID: 1,
+	}
+}
+
+// Subscribe simulates the subscription verification request and validates the echoed challenge
+func (s *WebhookSimulator) Subscribe(ctx context.Context, verifyToken string) error {
+	return handshake(ctx, s.Client, s.CallbackURL, verifyToken)
+}
+
+// ActivityCreated posts an activity create event
+func (s *WebhookSimulator) ActivityCreated(ctx context.Context, activityID int64) error {
+	return s.Send(ctx, s.message("activity", "create", activityID, nil))
+}
+
+// ActivityUpdated posts an activity update event with the updates (eg title, type, private)
+func (s *WebhookSimulator) ActivityUpdated(ctx context.Context, activityID int64, updates map[string]string) error {
+	return s.Send(ctx, s.message("activity", "update", activityID, updates))
+}
+
+// ActivityDeleted posts an activity delete event
+func (s *WebhookSimulator) ActivityDeleted(ctx context.Context, activityID int64) error {
+	return s.Send(ctx, s.message("activity", "delete", activityID, nil))
+}
+
+// AthleteDeauthorized posts an athlete deauthorization event
+func (s *WebhookSimulator) AthleteDeauthorized(ctx context.Context) error {
+	return s.Send(ctx, s.message("athlete", "update", int64(s.OwnerID), map[string]string{"authorized": "false"}))
+}
+
+// Send posts the message to the callback URL
+func (s *WebhookSimulator) Send(ctx context.Context, msg *WebhookMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("event request failed with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+func (s *WebhookSimulator) message(objectType, aspectType string, objectID int64, updates map[string]string) *WebhookMessage {
+	return &WebhookMessage{
+		ObjectType:     objectType,
+		ObjectID:       objectID,
+		AspectType:     aspectType,
+		OwnerID:        s.OwnerID,
+		SubscriptionID: s.SubscriptionID,
+		EventTime:      int(time.Now().Unix()),
+		Updates:        updates,
+	}
+}
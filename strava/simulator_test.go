@@ -0,0 +1,46 @@
+package strava_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestWebhookSimulator(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	sub := &TestSubscriber{}
+	svr := httptest.NewServer(strava.NewWebhookHandler(sub, strava.WithVerifyToken("verify")))
+	defer svr.Close()
+
+	ctx := context.Background()
+	sim := strava.NewWebhookSimulator(svr.URL, 88)
+	a.NoError(sim.Subscribe(ctx, "verify"))
+	a.Equal("verify", sub.verify)
+	a.Error(sim.Subscribe(ctx, "wrong"))
+
+	a.NoError(sim.ActivityUpdated(ctx, 12, map[string]string{"private": "true"}))
+	a.Equal(88, sub.msg.OwnerID)
+	a.Equal(int64(12), sub.msg.ObjectID)
+	evt, err := sub.msg.Event()
+	a.NoError(err)
+	a.True(*evt.(*strava.ActivityUpdated).Private)
+
+	a.NoError(sim.AthleteDeauthorized(ctx))
+	evt, err = sub.msg.Event()
+	a.NoError(err)
+	a.Equal(strava.EventAthleteDeauthorized, evt.EventType())
+
+	// a handler which does not echo the challenge fails verification
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"hub.challenge":"nope"}`))
+	}))
+	defer bad.Close()
+	a.Error(strava.NewWebhookSimulator(bad.URL, 88).Subscribe(ctx, "verify"))
+}
@@ -1,6 +1,6 @@
 package strava
 
-//go:generate genwith --do --client --endpoint-func --config --token --ratelimit --package strava
+//go:generate genwith --client --endpoint-func --config --token --ratelimit --package strava
 
 import (
 	"bytes"
@@ -42,6 +42,8 @@ type Client struct {
 	baseURL string
 
 	Auth     *AuthService
+	Club     *ClubService
+	Gear     *GearService
 	Route    *RouteService
 	Webhook  *WebhookService
 	Athlete  *AthleteService
@@ -49,6 +51,9 @@ type Client struct {
 }
 
 // Uploader returns an Uploader for this client
+//
+// The metadata of each uploaded file sets the name, description, external id, trainer
+// and commute fields of the activity.
 func (c *Client) Uploader() activity.Uploader {
 	return newUploader(c.Activity)
 }
@@ -58,6 +63,11 @@ func (c *Client) Exporter() activity.Exporter {
 	return c.Activity
 }
 
+// RouteExporter returns an Exporter for routes for this client
+func (c *Client) RouteExporter() activity.Exporter {
+	return c.Route
+}
+
 // WithBaseURL specifies the base url
 func WithBaseURL(baseURL string) Option {
 	return func(c *Client) error {
@@ -69,6 +79,8 @@ func WithBaseURL(baseURL string) Option {
 func withServices() Option {
 	return func(c *Client) error {
 		c.Auth = &AuthService{client: c}
+		c.Club = &ClubService{client: c}
+		c.Gear = &GearService{client: c}
 		c.Route = &RouteService{client: c}
 		c.Webhook = &WebhookService{client: c}
 		c.Athlete = &AthleteService{client: c}
@@ -122,3 +134,64 @@ func (c *Client) newWebhookRequest(
 	}
 	return req, nil
 }
+
+// paginator queries pages of entities from an endpoint supporting `page` and `per_page`
+type paginator[T any] struct {
+	uri      string
+	client   *Client
+	entities []T
+}
+
+func (p *paginator[T]) PageSize() int {
+	return PageSize
+}
+
+func (p *paginator[T]) Count() int {
+	return len(p.entities)
+}
+
+func (p *paginator[T]) Do(ctx context.Context, spec activity.Pagination) (int, error) {
+	uri := fmt.Sprintf("%s?page=%d&per_page=%d", p.uri, spec.Start, spec.Count)
+	req, err := p.client.newAPIRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return 0, err
+	}
+	var res []T
+	if err = p.client.do(req, &res); err != nil {
+		return 0, err
+	}
+	if spec.Total > 0 && len(p.entities)+len(res) > spec.Total {
+		res = res[:spec.Total-len(p.entities)]
+	}
+	p.entities = append(p.entities, res...)
+	return len(res), nil
+}
+
+// paginate queries all the entities at `uri` according to the pagination spec
+func paginate[T any](ctx context.Context, client *Client, uri string, spec activity.Pagination) ([]T, error) {
+	p := &paginator[T]{uri: uri, client: client, entities: make([]T, 0)}
+	if err := activity.Paginate(ctx, p, spec); err != nil {
+		return nil, err
+	}
+	return p.entities, nil
+}
+
+// do executes the http request and populates v with the result
+//
+// A response with an error status is returned as a *Fault.
+func (c *Client) do(req *http.Request, v any) error {
+	return activity.Do(c.client, req, v, newFault)
+}
+
+// newFault returns a Fault from the error response
+func newFault(res *http.Response) *Fault {
+	fault := &Fault{}
+	fault.FaultStatus = activity.DecodeFault(res, fault)
+	if fault.Code == 0 {
+		fault.Code = res.StatusCode
+	}
+	if fault.Message == "" {
+		fault.Message = http.StatusText(res.StatusCode)
+	}
+	return fault
+}
@@ -2,6 +2,8 @@ package strava
 
 import (
 	"context"
+	"errors"
+	"net/url"
 
 	"github.com/bzimmer/activity"
 )
@@ -15,6 +17,86 @@ import (
 //   status of your upload. Strava recommends polling no more than once a second. The mean processing
 //   time is around 8 seconds.
 
+// UploadOption sets metadata of the activity created by an upload
+type UploadOption func(url.Values) error
+
+// WithUploadName sets the name of the activity
+func WithUploadName(name string) UploadOption {
+	return func(v url.Values) error {
+		v.Set("name", name)
+		return nil
+	}
+}
+
+// WithUploadDescription sets the description of the activity
+func WithUploadDescription(description string) UploadOption {
+	return func(v url.Values) error {
+		v.Set("description", description)
+		return nil
+	}
+}
+
+// WithUploadTrainer marks whether the activity was completed on a trainer
+func WithUploadTrainer(trainer bool) UploadOption {
+	return func(v url.Values) error {
+		v.Set("trainer", boolFlag(trainer))
+		return nil
+	}
+}
+
+// WithUploadCommute marks whether the activity was a commute
+func WithUploadCommute(commute bool) UploadOption {
+	return func(v url.Values) error {
+		v.Set("commute", boolFlag(commute))
+		return nil
+	}
+}
+
+// WithExternalID sets the external identifier of the upload
+//
+// Strava rejects an upload as a duplicate if an activity with the same external id exists
+// which makes retries of the same upload idempotent.
+func WithExternalID(externalID string) UploadOption {
+	return func(v url.Values) error {
+		if externalID == "" {
+			return errors.New("empty external id")
+		}
+		v.Set("external_id", externalID)
+		return nil
+	}
+}
+
+func boolFlag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// uploadOptions returns the options for the file's metadata
+func uploadOptions(md *activity.UploadMetadata) []UploadOption {
+	if md == nil {
+		return nil
+	}
+	var opts []UploadOption
+	if md.Name != "" {
+		opts = append(opts, WithUploadName(md.Name))
+	}
+	if md.Description != "" {
+		opts = append(opts, WithUploadDescription(md.Description))
+	}
+	if md.ExternalID != "" {
+		opts = append(opts, WithExternalID(md.ExternalID))
+	}
+	if md.Trainer != nil {
+		opts = append(opts, WithUploadTrainer(*md.Trainer))
+	}
+	if md.Commute != nil {
+		opts = append(opts, WithUploadCommute(*md.Commute))
+	}
+	return opts
+}
+
 type uploader struct {
 	s *ActivityService
 }
@@ -23,8 +105,12 @@ func newUploader(s *ActivityService) activity.Uploader {
 	return &uploader{s: s}
 }
 
+// Upload uploads the file setting the activity's metadata from the file's metadata
 func (u *uploader) Upload(ctx context.Context, file *activity.File) (activity.Upload, error) {
-	return u.s.Upload(ctx, file)
+	if file == nil {
+		return nil, errors.New("missing upload file")
+	}
+	return u.s.Upload(ctx, file, uploadOptions(file.Metadata)...)
 }
 
 func (u *uploader) Status(ctx context.Context, id activity.UploadID) (activity.Upload, error) {
@@ -19,3 +19,38 @@ func TestUploadDone(t *testing.T) {
 	a.True((&strava.Upload{Error: "error"}).Done())
 	a.True((&strava.Upload{ActivityID: 1234567890}).Done())
 }
+
+func TestUploadOutcome(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name       string
+		upload     *strava.Upload
+		status     activity.UploadStatus
+		activityID int64
+	}{
+		{name: "pending", upload: &strava.Upload{Status: "Your activity is still being processed."},
+			status: activity.UploadPending},
+		{name: "created", upload: &strava.Upload{ActivityID: 1234567890, Status: "Your activity is ready."},
+			status: activity.UploadCreated, activityID: 1234567890},
+		{name: "duplicate link", upload: &strava.Upload{
+			Error: "LongHike.gpx duplicate of <a href='/activities/4567' target='_blank'>Long Hike</a>"},
+			status: activity.UploadDuplicate, activityID: 4567},
+		{name: "duplicate text", upload: &strava.Upload{Error: "LongHike.gpx duplicate of activity 4568"},
+			status: activity.UploadDuplicate, activityID: 4568},
+		{name: "invalid", upload: &strava.Upload{Error: "Error parsing file."},
+			status: activity.UploadInvalid},
+		{name: "failed", upload: &strava.Upload{Error: "There was an error processing your activity."},
+			status: activity.UploadFailed},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			outcome := activity.Outcome(tt.upload)
+			a.Equal(tt.status, outcome.Status)
+			a.Equal(tt.activityID, outcome.ActivityID)
+		})
+	}
+}
@@ -2,14 +2,22 @@ package strava
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
+
+	"golang.org/x/oauth2"
 )
 
 //  API documented at https://developers.strava.com/docs/webhooks/
 
+// callbackTimeout bounds the verification handshake if the http client has no timeout
+const callbackTimeout = 10 * time.Second
+
 // WebhookService is the API for webhook endpoints
 type WebhookService service
 
@@ -28,6 +36,21 @@ type WebhookSubscription struct {
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+// WebhookSubscriptionChange reports the changes made to reconcile the webhook subscription
+type WebhookSubscriptionChange struct {
+	// Subscription is the existing subscription if it already matched the callback URL
+	Subscription *WebhookSubscription `json:"subscription,omitempty"`
+	// Deleted are the subscriptions removed because the callback URL differed
+	Deleted []*WebhookSubscription `json:"deleted,omitempty"`
+	// Created is the acknowledgement of a newly created subscription
+	Created *WebhookAcknowledgement `json:"created,omitempty"`
+}
+
+// Changed returns true if any subscriptions were deleted or created
+func (c *WebhookSubscriptionChange) Changed() bool {
+	return len(c.Deleted) > 0 || c.Created != nil
+}
+
 // WebhookMessage is the incoming webhook message
 type WebhookMessage struct {
 	ObjectType     string            `json:"object_type"`
@@ -93,73 +116,110 @@ func (s *WebhookService) List(ctx context.Context) ([]*WebhookSubscription, erro
 	return subs, err
 }
 
-// webhookSubscriptionHandler handles subscription requests from Strava (GET)
-func webhookSubscriptionHandler(subscriber WebhookSubscriber) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		q := r.URL.Query()
-		verify, ok := q["hub.verify_token"]
-		if !ok && len(verify) == 1 {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		challenge, ok := q["hub.challenge"]
-		if !ok && len(challenge) == 1 {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		if subscriber != nil {
-			// if err is not nil the verification failed
-			err := subscriber.SubscriptionRequest(challenge[0], verify[0])
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-		}
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(map[string]string{"hub.challenge": challenge[0]}); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+// EnsureSubscription reconciles the application's webhook subscription with the callback URL
+//
+// Strava allows only one subscription per application so any existing subscription with a
+// different callback URL must be deleted before subscribing. To avoid leaving the application
+// without a subscription the callback URL is first checked with a verification handshake of
+// its own and existing subscriptions are deleted only if it succeeds: a handler returned by
+// NewWebhookHandler must be serving the callback URL and its subscriber's SubscriptionRequest
+// must accept the verify token.
+func (s *WebhookService) EnsureSubscription(
+	ctx context.Context, callbackURL, verifyToken string) (*WebhookSubscriptionChange, error) {
+	subs, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	change := &WebhookSubscriptionChange{}
+	for _, sub := range subs {
+		if sub.CallbackURL == callbackURL {
+			change.Subscription = sub
+			break
 		}
 	}
-}
-
-// webhookEventHandler receives the webhook callbacks from Strava (POST)
-func webhookEventHandler(subscriber WebhookSubscriber) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		m := &WebhookMessage{}
-		err := json.NewDecoder(r.Body).Decode(m)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
+	if change.Subscription == nil {
+		if err = s.VerifyCallback(ctx, callbackURL, verifyToken); err != nil {
+			return change, err
 		}
-		if subscriber != nil {
-			err = subscriber.MessageReceived(m)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				return
-			}
+	}
+	for _, sub := range subs {
+		if sub == change.Subscription {
+			continue
 		}
-		w.Header().Set("Content-Type", "application/json")
-		if err = json.NewEncoder(w).Encode(map[string]string{"status": "ok"}); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+		if err = s.Unsubscribe(ctx, sub.ID); err != nil {
+			return change, err
 		}
+		change.Deleted = append(change.Deleted, sub)
 	}
+	if change.Subscription != nil {
+		return change, nil
+	}
+	change.Created, err = s.Subscribe(ctx, callbackURL, verifyToken)
+	if err != nil {
+		return change, err
+	}
+	return change, nil
 }
 
-type webhookHandler struct {
-	sub WebhookSubscriber
+// VerifyCallback performs the subscription verification handshake against the callback URL
+//
+// The handshake is the same as Strava's: a GET request with a random challenge and the verify
+// token which must respond with the challenge. The request uses the client's http client without
+// its oauth2 transport and is bounded by a timeout if the http client has none.
+func (s *WebhookService) VerifyCallback(ctx context.Context, callbackURL, verifyToken string) error {
+	return handshake(ctx, s.client.callbackClient(), callbackURL, verifyToken)
 }
 
-func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		webhookSubscriptionHandler(h.sub)(w, r)
-	case http.MethodPost:
-		webhookEventHandler(h.sub)(w, r)
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+// callbackClient returns an http client for requests to a webhook callback
+//
+// The callback is not a Strava endpoint so the client's credentials must not be sent.
+func (c *Client) callbackClient() *http.Client {
+	client := *c.client
+	if t, ok := client.Transport.(*oauth2.Transport); ok {
+		client.Transport = t.Base
+	}
+	if client.Timeout == 0 {
+		client.Timeout = callbackTimeout
 	}
+	return &client
 }
 
-// NewWebhookHandler returns a http.Handler for servicing webhook requests
-func NewWebhookHandler(sub WebhookSubscriber) http.Handler {
-	return &webhookHandler{sub: sub}
+// handshake performs the subscription verification handshake against the callback URL
+func handshake(ctx context.Context, client *http.Client, callbackURL, verifyToken string) error {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	challenge := hex.EncodeToString(b)
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("hub.mode", "subscribe")
+	q.Set("hub.challenge", challenge)
+	q.Set("hub.verify_token", verifyToken)
+	u.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("callback verification failed with status %d", res.StatusCode)
+	}
+	var ack map[string]string
+	if err = json.NewDecoder(res.Body).Decode(&ack); err != nil {
+		return fmt.Errorf("callback verification failed: %w", err)
+	}
+	if ack["hub.challenge"] != challenge {
+		return fmt.Errorf("callback verification failed: expected challenge '%s' but received '%s'",
+			challenge, ack["hub.challenge"])
+	}
+	return nil
 }
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -232,3 +235,174 @@ func TestWebhookSubscriptionHandler(t *testing.T) {
 
 	a.Equal(500, w.Code)
 }
+
+func TestWebhookEnsureSubscription(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	router := strava.NewWebhookRouter()
+	router.OnSubscriptionRequest(func(_, verify string) error {
+		if verify != "verifyToken123" {
+			return errors.New("invalid verify token")
+		}
+		return nil
+	})
+	cb := httptest.NewServer(strava.NewWebhookHandler(router))
+	t.Cleanup(cb.Close)
+	missing := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(missing.Close)
+
+	// subscribe performs the verification handshake against the callback url as Strava would
+	subscribe := func(w http.ResponseWriter, r *http.Request) {
+		q := url.Values{}
+		q.Set("hub.mode", "subscribe")
+		q.Set("hub.challenge", "15f7d1a91c1f40f8a748fd134752feb3")
+		q.Set("hub.verify_token", r.FormValue("verify_token"))
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, r.FormValue("callback_url")+"?"+q.Encode(), nil)
+		a.NoError(err)
+		res, err := http.DefaultClient.Do(req)
+		a.NoError(err)
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Bad Request","errors":[{"resource":"PushSubscription","field":"callback url","code":"GET to callback URL does not return 200"}]}`))
+			return
+		}
+		http.ServeFile(w, r, "testdata/webhook_subscribe.json")
+	}
+
+	tests := []struct {
+		name        string
+		callbackURL string
+		verifyToken string
+		deleted     int
+		after       func(*strava.WebhookSubscriptionChange, error)
+	}{
+		{
+			name:        "unchanged",
+			callbackURL: "https://4cbd9ddb9748.ngrok.io/strava/webhook",
+			verifyToken: "verifyToken123",
+			after: func(change *strava.WebhookSubscriptionChange, err error) {
+				a.NoError(err)
+				a.False(change.Changed())
+				a.Equal(int64(887228), change.Subscription.ID)
+			},
+		},
+		{
+			name:        "replaced",
+			callbackURL: cb.URL,
+			verifyToken: "verifyToken123",
+			deleted:     1,
+			after: func(change *strava.WebhookSubscriptionChange, err error) {
+				a.NoError(err)
+				a.True(change.Changed())
+				a.Nil(change.Subscription)
+				a.Len(change.Deleted, 1)
+				a.Equal(int64(887228), change.Deleted[0].ID)
+				a.Equal(int64(887228), change.Created.ID)
+			},
+		},
+		{
+			name:        "failed handshake",
+			callbackURL: cb.URL,
+			verifyToken: "wrongToken",
+			after: func(change *strava.WebhookSubscriptionChange, err error) {
+				// the existing subscription is kept since the new callback cannot be verified
+				a.Error(err)
+				a.Empty(change.Deleted)
+				a.Nil(change.Created)
+			},
+		},
+		{
+			name:        "callback not served",
+			callbackURL: missing.URL,
+			verifyToken: "verifyToken123",
+			after: func(change *strava.WebhookSubscriptionChange, err error) {
+				a.Error(err)
+				a.Empty(change.Deleted)
+				a.Nil(change.Created)
+			},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var deleted int
+			client, svr := newClientMust(func(mux *http.ServeMux) {
+				mux.HandleFunc("/push_subscriptions", func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case http.MethodGet:
+						http.ServeFile(w, r, "testdata/subscriptions.json")
+					case http.MethodPost:
+						a.Equal(1, deleted)
+						subscribe(w, r)
+					}
+				})
+				mux.HandleFunc("/push_subscriptions/887228", func(w http.ResponseWriter, r *http.Request) {
+					a.Equal(http.MethodDelete, r.Method)
+					deleted++
+					w.WriteHeader(http.StatusNoContent)
+				})
+			}, strava.WithClientCredentials("someID", "someSecret"))
+			defer svr.Close()
+			tt.after(client.Webhook.EnsureSubscription(context.TODO(), tt.callbackURL, tt.verifyToken))
+			a.Equal(tt.deleted, deleted)
+		})
+	}
+}
+
+type countingTransport struct {
+	n atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.n.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWebhookVerifyCallback(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	router := strava.NewWebhookRouter()
+	router.OnSubscriptionRequest(func(_, _ string) error { return nil })
+	cb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the callback is not a Strava endpoint so no credentials are sent
+		a.Empty(r.Header.Get("Authorization"))
+		strava.NewWebhookHandler(router).ServeHTTP(w, r)
+	}))
+	defer cb.Close()
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer hung.Close()
+	defer close(release)
+
+	t.Run("configured transport", func(t *testing.T) {
+		transport := &countingTransport{}
+		client, svr := newClientMust(func(*http.ServeMux) {}, strava.WithTransport(transport))
+		defer svr.Close()
+		a.NoError(client.Webhook.VerifyCallback(context.TODO(), cb.URL, "verify"))
+		a.Equal(int32(1), transport.n.Load())
+	})
+
+	t.Run("credentials not sent", func(t *testing.T) {
+		client, svr := newClientMust(func(*http.ServeMux) {}, strava.WithAutoRefresh(context.TODO()))
+		defer svr.Close()
+		a.NoError(client.Webhook.VerifyCallback(context.TODO(), cb.URL, "verify"))
+	})
+
+	t.Run("hung callback", func(t *testing.T) {
+		client, svr := newClientMust(func(*http.ServeMux) {},
+			strava.WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+		defer svr.Close()
+		start := time.Now()
+		a.Error(client.Webhook.VerifyCallback(context.TODO(), hung.URL, "verify"))
+		a.Less(time.Since(start), 5*time.Second)
+	})
+}
@@ -0,0 +1,63 @@
+package strava
+
+import (
+	"errors"
+
+	"github.com/martinlindhe/unit"
+)
+
+// Zone returns the index of the zone containing the value or -1 if no zone contains the value
+func (z ZoneRanges) Zone(value float64) int {
+	for i, r := range z {
+		if value >= r.Min && (r.Max < 0 || value < r.Max) {
+			return i
+		}
+	}
+	return -1
+}
+
+// TimeInZones returns the time spent in each zone for the values of the stream
+//
+// The time between consecutive samples is attributed to the zone of the later sample.
+func (z ZoneRanges) TimeInZones(times, values *Stream) ([]*TimedZoneRange, error) {
+	if times == nil || values == nil {
+		return nil, errors.New("both time and value streams are required")
+	}
+	if len(times.Data) != len(values.Data) {
+		return nil, errors.New("time and value streams differ in length")
+	}
+	dist := make([]*TimedZoneRange, len(z))
+	for i, r := range z {
+		dist[i] = &TimedZoneRange{Min: r.Min, Max: r.Max}
+	}
+	for i := 1; i < len(times.Data); i++ {
+		n := z.Zone(values.Data[i])
+		if n < 0 {
+			continue
+		}
+		dist[n].Time += unit.Duration(times.Data[i]-times.Data[i-1]) * unit.Second
+	}
+	return dist, nil
+}
+
+// HeartRateDistribution returns the time spent in each heart rate zone for the streams
+func (a *AthleteZones) HeartRateDistribution(streams *Streams) ([]*TimedZoneRange, error) {
+	if a.HeartRate == nil {
+		return nil, errors.New("no heart rate zones available")
+	}
+	if streams == nil {
+		return nil, errors.New("no streams available")
+	}
+	return a.HeartRate.Zones.TimeInZones(streams.Time, streams.HeartRate)
+}
+
+// PowerDistribution returns the time spent in each power zone for the streams
+func (a *AthleteZones) PowerDistribution(streams *Streams) ([]*TimedZoneRange, error) {
+	if a.Power == nil {
+		return nil, errors.New("no power zones available")
+	}
+	if streams == nil {
+		return nil, errors.New("no streams available")
+	}
+	return a.Power.Zones.TimeInZones(streams.Time, streams.Watts)
+}
@@ -0,0 +1,97 @@
+package strava_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+func TestZoneRanges(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	zones := strava.ZoneRanges{
+		{Min: 0, Max: 100},
+		{Min: 100, Max: 200},
+		{Min: 200, Max: -1},
+	}
+	a.Equal(0, zones.Zone(0))
+	a.Equal(1, zones.Zone(100))
+	a.Equal(2, zones.Zone(2000))
+	a.Equal(-1, zones.Zone(-10))
+
+	tests := []struct {
+		name          string
+		times, values *strava.Stream
+		dist          []float64
+		err           bool
+	}{
+		{
+			name: "missing stream",
+			err:  true,
+		},
+		{
+			name:   "mismatched lengths",
+			times:  &strava.Stream{Data: []float64{0, 1, 2}},
+			values: &strava.Stream{Data: []float64{50, 150}},
+			err:    true,
+		},
+		{
+			name:   "distribution",
+			times:  &strava.Stream{Data: []float64{0, 1, 2, 4, 5, 10}},
+			values: &strava.Stream{Data: []float64{50, 50, 150, 250, -5, 120}},
+			dist:   []float64{1, 6, 2},
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dist, err := zones.TimeInZones(tt.times, tt.values)
+			if tt.err {
+				a.Error(err)
+				a.Nil(dist)
+				return
+			}
+			a.NoError(err)
+			a.Len(dist, len(tt.dist))
+			for j := range tt.dist {
+				a.Equal(tt.dist[j], dist[j].Time.Seconds())
+			}
+		})
+	}
+}
+
+func TestAthleteZonesDistribution(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	streams := &strava.Streams{
+		Time:      &strava.Stream{Data: []float64{0, 10, 20}},
+		HeartRate: &strava.Stream{Data: []float64{100, 120, 160}},
+		Watts:     &strava.Stream{Data: []float64{0, 300, 300}},
+	}
+
+	zones := &strava.AthleteZones{}
+	_, err := zones.HeartRateDistribution(streams)
+	a.Error(err)
+	_, err = zones.PowerDistribution(streams)
+	a.Error(err)
+
+	zones = &strava.AthleteZones{
+		HeartRate: &strava.HeartRateZoneRanges{Zones: strava.ZoneRanges{{Min: 0, Max: 150}, {Min: 150, Max: -1}}},
+		Power:     &strava.PowerZoneRanges{Zones: strava.ZoneRanges{{Min: 0, Max: 250}, {Min: 250, Max: -1}}},
+	}
+	hr, err := zones.HeartRateDistribution(streams)
+	a.NoError(err)
+	a.Equal(float64(10), hr[0].Time.Seconds())
+	a.Equal(float64(10), hr[1].Time.Seconds())
+	pwr, err := zones.PowerDistribution(streams)
+	a.NoError(err)
+	a.Equal(float64(0), pwr[0].Time.Seconds())
+	a.Equal(float64(20), pwr[1].Time.Seconds())
+	_, err = zones.PowerDistribution(nil)
+	a.Error(err)
+}
@@ -0,0 +1,195 @@
+// Package trainingload computes fitness, fatigue, and form from the training impulse of workouts
+//
+// Fitness (chronic training load) and fatigue (acute training load) are exponentially weighted
+// moving averages of the daily training impulse, form (training stress balance) is their difference.
+package trainingload
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/bzimmer/activity/strava"
+)
+
+const (
+	// ChronicDays is the default time constant, in days, for fitness
+	ChronicDays = 42
+	// AcuteDays is the default time constant, in days, for fatigue
+	AcuteDays = 7
+)
+
+// Method used to calculate the training impulse of a workout
+type Method string
+
+const (
+	// MethodNone is used when no impulse could be calculated
+	MethodNone Method = "none"
+	// MethodPower calculates the training stress score (TSS) from power and FTP
+	MethodPower Method = "power"
+	// MethodHeartRate calculates Banister's training impulse (TRIMP) from heart rate
+	MethodHeartRate Method = "heartrate"
+	// MethodRelativeEffort uses the relative effort reported by the provider
+	MethodRelativeEffort Method = "relative_effort"
+)
+
+// Thresholds of the athlete used to calculate training impulse
+type Thresholds struct {
+	// FTP is the functional threshold power in watts
+	FTP float64
+	// RestingHeartRate in beats per minute
+	RestingHeartRate float64
+	// MaxHeartRate in beats per minute
+	MaxHeartRate float64
+	// Female uses the female weighting factors for TRIMP
+	Female bool
+}
+
+// Workout is a provider independent summary of an activity
+type Workout struct {
+	// ID of the activity
+	ID int64
+	// Date of the activity in the athlete's local time
+	Date time.Time
+	// MovingTime of the activity
+	MovingTime time.Duration
+	// WeightedPower is the normalized (or weighted average) power in watts
+	WeightedPower float64
+	// AverageHeartRate in beats per minute
+	AverageHeartRate float64
+	// RelativeEffort is a provider calculated effort score (eg Strava's suffer score)
+	RelativeEffort float64
+}
+
+// An Option allows configuring the calculator
+type Option func(c *Calculator)
+
+// WithTimeConstants sets the time constants, in days, for fitness and fatigue
+func WithTimeConstants(chronic, acute int) Option {
+	return func(c *Calculator) {
+		if chronic > 0 {
+			c.chronic = chronic
+		}
+		if acute > 0 {
+			c.acute = acute
+		}
+	}
+}
+
+// Calculator computes training impulse and load
+type Calculator struct {
+	thresholds Thresholds
+	chronic    int
+	acute      int
+}
+
+// NewCalculator returns a new calculator for an athlete's thresholds
+func NewCalculator(thresholds Thresholds, opts ...Option) *Calculator {
+	c := &Calculator{thresholds: thresholds, chronic: ChronicDays, acute: AcuteDays}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Impulse returns the training impulse of the workout and the method used to calculate it
+//
+// Power is preferred, followed by heart rate and finally relative effort.
+func (c *Calculator) Impulse(w *Workout) (float64, Method) {
+	hours := w.MovingTime.Hours()
+	th := c.thresholds
+	switch {
+	case w.WeightedPower > 0 && th.FTP > 0 && hours > 0:
+		intensity := w.WeightedPower / th.FTP
+		return hours * intensity * intensity * 100, MethodPower
+	case w.AverageHeartRate > 0 && th.MaxHeartRate > th.RestingHeartRate && hours > 0:
+		reserve := (w.AverageHeartRate - th.RestingHeartRate) / (th.MaxHeartRate - th.RestingHeartRate)
+		reserve = math.Max(0, math.Min(1, reserve))
+		a, b := 0.64, 1.92
+		if th.Female {
+			a, b = 0.86, 1.67
+		}
+		return w.MovingTime.Minutes() * reserve * a * math.Exp(b*reserve), MethodHeartRate
+	case w.RelativeEffort > 0:
+		return w.RelativeEffort, MethodRelativeEffort
+	default:
+		return 0, MethodNone
+	}
+}
+
+// Load returns the daily training load for each day in the date range
+//
+// All workouts prior to `from` contribute to the fitness and fatigue of the first day in the range.
+// A zero `from` or `to` defaults to the date of the first or last workout respectively.
+func (c *Calculator) Load(workouts []*Workout, from, to time.Time) ([]*strava.TrainingLoad, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, errors.New("invalid date range")
+	}
+	var first, last time.Time
+	days := make(map[time.Time][]*Workout)
+	for _, w := range workouts {
+		d := day(w.Date)
+		days[d] = append(days[d], w)
+		if first.IsZero() || d.Before(first) {
+			first = d
+		}
+		if last.IsZero() || d.After(last) {
+			last = d
+		}
+	}
+	start, end := day(from), day(to)
+	if from.IsZero() {
+		start = first
+	}
+	if to.IsZero() {
+		end = last
+	}
+	if start.IsZero() || end.IsZero() {
+		return []*strava.TrainingLoad{}, nil
+	}
+	history := start
+	if !first.IsZero() && first.Before(start) {
+		history = first
+	}
+
+	var fitness, fatigue float64
+	loads := make([]*strava.TrainingLoad, 0)
+	for d := history; !d.After(end); d = d.AddDate(0, 0, 1) {
+		var impulse, effort float64
+		profiles := make([]*strava.ActivityProfile, 0, len(days[d]))
+		for _, w := range days[d] {
+			x, _ := c.Impulse(w)
+			impulse += x
+			effort += w.RelativeEffort
+			profiles = append(profiles, &strava.ActivityProfile{
+				ID:             w.ID,
+				Impulse:        int(math.Round(x)),
+				RelativeEffort: int(math.Round(w.RelativeEffort)),
+			})
+		}
+		fitness += (impulse - fitness) / float64(c.chronic)
+		fatigue += (impulse - fatigue) / float64(c.acute)
+		if d.Before(start) {
+			continue
+		}
+		loads = append(loads, &strava.TrainingLoad{
+			TrainingDate: &strava.TrainingDate{Year: d.Year(), Month: int(d.Month()), Day: d.Day()},
+			FitnessProfile: &strava.FitnessProfile{
+				Fitness:        fitness,
+				Fatigue:        fatigue,
+				Form:           fitness - fatigue,
+				Impulse:        int(math.Round(impulse)),
+				RelativeEffort: int(math.Round(effort)),
+			},
+			Activities: profiles,
+		})
+	}
+	return loads, nil
+}
+
+func day(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
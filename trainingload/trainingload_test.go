@@ -0,0 +1,170 @@
+package trainingload_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/trainingload"
+)
+
+func TestImpulse(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	thresholds := trainingload.Thresholds{FTP: 250, RestingHeartRate: 50, MaxHeartRate: 190}
+	tests := []struct {
+		name       string
+		thresholds trainingload.Thresholds
+		workout    *trainingload.Workout
+		impulse    float64
+		method     trainingload.Method
+	}{
+		{
+			name:       "power at ftp for an hour",
+			thresholds: thresholds,
+			workout:    &trainingload.Workout{MovingTime: time.Hour, WeightedPower: 250, AverageHeartRate: 155},
+			impulse:    100,
+			method:     trainingload.MethodPower,
+		},
+		{
+			name:       "heart rate without ftp",
+			thresholds: trainingload.Thresholds{RestingHeartRate: 50, MaxHeartRate: 190},
+			workout:    &trainingload.Workout{MovingTime: time.Hour, WeightedPower: 250, AverageHeartRate: 155},
+			impulse:    60 * 0.75 * 0.64 * math.Exp(1.92*0.75),
+			method:     trainingload.MethodHeartRate,
+		},
+		{
+			name:       "heart rate female",
+			thresholds: trainingload.Thresholds{RestingHeartRate: 50, MaxHeartRate: 190, Female: true},
+			workout:    &trainingload.Workout{MovingTime: time.Hour, AverageHeartRate: 155},
+			impulse:    60 * 0.75 * 0.86 * math.Exp(1.67*0.75),
+			method:     trainingload.MethodHeartRate,
+		},
+		{
+			name:       "relative effort",
+			thresholds: thresholds,
+			workout:    &trainingload.Workout{MovingTime: time.Hour, RelativeEffort: 82},
+			impulse:    82,
+			method:     trainingload.MethodRelativeEffort,
+		},
+		{
+			name:       "nothing",
+			thresholds: thresholds,
+			workout:    &trainingload.Workout{MovingTime: time.Hour},
+			impulse:    0,
+			method:     trainingload.MethodNone,
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			calc := trainingload.NewCalculator(tt.thresholds)
+			impulse, method := calc.Impulse(tt.workout)
+			a.InDelta(tt.impulse, impulse, 0.0001)
+			a.Equal(tt.method, method)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 7, 30, 0, 0, time.UTC)
+	}
+	workouts := []*trainingload.Workout{
+		{ID: 1, Date: day(1), MovingTime: time.Hour, WeightedPower: 250},
+		{ID: 2, Date: day(3), MovingTime: time.Hour, WeightedPower: 250},
+		{ID: 3, Date: day(3), MovingTime: time.Hour, RelativeEffort: 20},
+	}
+	calc := trainingload.NewCalculator(trainingload.Thresholds{FTP: 250})
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		days     int
+		err      bool
+		after    func(fitness, fatigue []float64)
+	}{
+		{
+			name: "all workouts",
+			days: 3,
+			after: func(fitness, fatigue []float64) {
+				a.InDelta(100.0/42, fitness[0], 0.0001)
+				a.InDelta(100.0/7, fatigue[0], 0.0001)
+				a.InDelta(fitness[0]-fitness[0]/42, fitness[1], 0.0001)
+			},
+		},
+		{
+			name: "history before the range",
+			from: day(2),
+			to:   day(5),
+			days: 4,
+			after: func(fitness, _ []float64) {
+				a.InDelta(100.0/42-(100.0/42)/42, fitness[0], 0.0001)
+			},
+		},
+		{
+			name: "invalid range",
+			from: day(5),
+			to:   day(2),
+			err:  true,
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			loads, err := calc.Load(workouts, tt.from, tt.to)
+			if tt.err {
+				a.Error(err)
+				a.Nil(loads)
+				return
+			}
+			a.NoError(err)
+			a.Len(loads, tt.days)
+			var fitness, fatigue []float64
+			for _, load := range loads {
+				p := load.FitnessProfile
+				a.InDelta(p.Fitness-p.Fatigue, p.Form, 0.0001)
+				fitness = append(fitness, p.Fitness)
+				fatigue = append(fatigue, p.Fatigue)
+			}
+			tt.after(fitness, fatigue)
+		})
+	}
+
+	loads, err := calc.Load(workouts, time.Time{}, time.Time{})
+	a.NoError(err)
+	a.Equal(2024, loads[2].TrainingDate.Year)
+	a.Equal(1, loads[2].TrainingDate.Month)
+	a.Equal(3, loads[2].TrainingDate.Day)
+	a.Equal(120, loads[2].FitnessProfile.Impulse)
+	a.Equal(20, loads[2].FitnessProfile.RelativeEffort)
+	a.Len(loads[2].Activities, 2)
+	a.Empty(loads[1].Activities)
+
+	loads, err = calc.Load(nil, time.Time{}, time.Time{})
+	a.NoError(err)
+	a.Empty(loads)
+}
+
+func TestTimeConstants(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	calc := trainingload.NewCalculator(
+		trainingload.Thresholds{FTP: 200}, trainingload.WithTimeConstants(28, 5))
+	loads, err := calc.Load([]*trainingload.Workout{
+		{ID: 1, Date: time.Now(), MovingTime: time.Hour, WeightedPower: 200},
+	}, time.Time{}, time.Time{})
+	a.NoError(err)
+	a.Len(loads, 1)
+	a.InDelta(100.0/28, loads[0].FitnessProfile.Fitness, 0.0001)
+	a.InDelta(100.0/5, loads[0].FitnessProfile.Fatigue, 0.0001)
+}
@@ -0,0 +1,68 @@
+package trainingload
+
+import (
+	"time"
+
+	"github.com/bzimmer/activity/cyclinganalytics"
+	"github.com/bzimmer/activity/rwgps"
+	"github.com/bzimmer/activity/strava"
+	"github.com/bzimmer/activity/zwift"
+)
+
+// FromStrava returns a Workout for a Strava activity
+func FromStrava(act *strava.Activity) *Workout {
+	return &Workout{
+		ID:               act.ID,
+		Date:             act.StartDateLocal,
+		MovingTime:       time.Duration(act.MovingTime.Seconds() * float64(time.Second)),
+		WeightedPower:    float64(act.WeightedAverageWatts),
+		AverageHeartRate: act.AverageHeartrate,
+		RelativeEffort:   act.SufferScore,
+	}
+}
+
+// FromCyclingAnalytics returns a Workout for a Cycling Analytics ride
+func FromCyclingAnalytics(ride *cyclinganalytics.Ride) *Workout {
+	return &Workout{
+		ID:               ride.ID,
+		Date:             ride.LocalDatetime.Time,
+		MovingTime:       time.Duration(ride.Summary.MovingTime * float64(time.Second)),
+		WeightedPower:    ride.Summary.Epower,
+		AverageHeartRate: ride.Summary.AvgHeartrate,
+	}
+}
+
+// FromRWGPS returns a Workout for a RWGPS trip
+//
+// RWGPS does not provide a weighted power so the average power is used
+func FromRWGPS(trip *rwgps.Trip) *Workout {
+	w := &Workout{
+		ID:         trip.ID,
+		Date:       trip.DepartedAt,
+		MovingTime: time.Duration(trip.Duration) * time.Second,
+	}
+	if m := trip.Metrics; m != nil {
+		if m.MovingTime > 0 {
+			w.MovingTime = time.Duration(m.MovingTime) * time.Second
+		}
+		if m.Watts != nil {
+			w.WeightedPower = m.Watts.Avg
+		}
+		if m.HeartRate != nil {
+			w.AverageHeartRate = m.HeartRate.Avg
+		}
+	}
+	return w
+}
+
+// FromZwift returns a Workout for a Zwift activity
+//
+// Zwift does not provide a weighted power so the average power is used
+func FromZwift(act *zwift.Activity) *Workout {
+	return &Workout{
+		ID:            act.ID,
+		Date:          act.StartDate.Time,
+		MovingTime:    time.Duration(act.MovingTimeInMillis) * time.Millisecond,
+		WeightedPower: act.AvgWatts,
+	}
+}
@@ -0,0 +1,90 @@
+package trainingload_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bzimmer/activity/cyclinganalytics"
+	"github.com/bzimmer/activity/rwgps"
+	"github.com/bzimmer/activity/strava"
+	"github.com/bzimmer/activity/trainingload"
+	"github.com/bzimmer/activity/zwift"
+)
+
+func TestWorkouts(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	now := time.Now()
+	tests := []struct {
+		name    string
+		workout *trainingload.Workout
+		power   float64
+		hr      float64
+		effort  float64
+	}{
+		{
+			name: "strava",
+			workout: trainingload.FromStrava(&strava.Activity{
+				ID:                   1,
+				StartDateLocal:       now,
+				MovingTime:           unit.Duration(3600),
+				WeightedAverageWatts: 210,
+				AverageHeartrate:     140.3,
+				SufferScore:          82,
+			}),
+			power:  210,
+			hr:     140.3,
+			effort: 82,
+		},
+		{
+			name: "cyclinganalytics",
+			workout: trainingload.FromCyclingAnalytics(&cyclinganalytics.Ride{
+				ID:            1,
+				LocalDatetime: cyclinganalytics.Datetime{Time: now},
+				Summary:       cyclinganalytics.Summary{MovingTime: 3600, Epower: 210, AvgHeartrate: 140.3},
+			}),
+			power: 210,
+			hr:    140.3,
+		},
+		{
+			name: "rwgps",
+			workout: trainingload.FromRWGPS(&rwgps.Trip{
+				ID:         1,
+				DepartedAt: now,
+				Metrics: &rwgps.Metrics{
+					MovingTime: 3600,
+					Watts:      &rwgps.Summary{Avg: 210},
+					HeartRate:  &rwgps.Summary{Avg: 140.3},
+				},
+			}),
+			power: 210,
+			hr:    140.3,
+		},
+		{
+			name: "zwift",
+			workout: trainingload.FromZwift(&zwift.Activity{
+				ID:                 1,
+				StartDate:          zwift.Datetime{Time: now},
+				MovingTimeInMillis: 3600 * 1000,
+				AvgWatts:           210,
+			}),
+			power: 210,
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a.Equal(int64(1), tt.workout.ID)
+			a.Equal(now, tt.workout.Date)
+			a.Equal(time.Hour, tt.workout.MovingTime)
+			a.Equal(tt.power, tt.workout.WeightedPower)
+			a.Equal(tt.hr, tt.workout.AverageHeartRate)
+			a.Equal(tt.effort, tt.workout.RelativeEffort)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -41,8 +43,80 @@ type Upload interface {
 	Done() bool
 }
 
+// UploadStatus classifies the outcome of an upload
+type UploadStatus string
+
+const (
+	// UploadPending the upload is still being processed
+	UploadPending UploadStatus = "pending"
+	// UploadCreated the upload created a new activity
+	UploadCreated UploadStatus = "created"
+	// UploadDuplicate the upload duplicates an existing activity
+	UploadDuplicate UploadStatus = "duplicate"
+	// UploadInvalid the uploaded file could not be parsed
+	UploadInvalid UploadStatus = "invalid"
+	// UploadFailed an error occurred processing the upload
+	UploadFailed UploadStatus = "failed"
+)
+
+// UploadOutcome is the provider-independent outcome of an upload
+type UploadOutcome struct {
+	Status UploadStatus `json:"status"`
+	// ActivityID is the id of the created activity or, for duplicates, the existing activity (if known)
+	ActivityID int64 `json:"activity_id,omitempty"`
+	// Message is the provider's message, if any
+	Message string `json:"message,omitempty"`
+}
+
+// UploadOutcomer is implemented by Uploads which can classify their outcome
+type UploadOutcomer interface {
+	// Outcome returns the classified outcome of the upload
+	Outcome() *UploadOutcome
+}
+
+// Outcome returns the classified outcome of the upload
+//
+// If the upload does not implement UploadOutcomer a completed upload is reported as failed
+// since success cannot be confirmed.
+func Outcome(upload Upload) *UploadOutcome {
+	if x, ok := upload.(UploadOutcomer); ok {
+		return x.Outcome()
+	}
+	if !upload.Done() {
+		return &UploadOutcome{Status: UploadPending}
+	}
+	return &UploadOutcome{Status: UploadFailed, Message: "unknown upload outcome"}
+}
+
+var (
+	// duplicateRE matches messages for duplicate uploads capturing the existing activity id if present
+	//
+	//	"LongHike.gpx duplicate of <a href='/activities/123' target='_blank'>Long Hike</a>"
+	//	"Duplicate of trip 123"
+	duplicateRE = regexp.MustCompile(`(?i)duplicate\D*(\d*)`)
+	// invalidRE matches messages for files which could not be parsed
+	invalidRE = regexp.MustCompile(`(?i)pars|format|empty|unrecognized|unsupported|malformed|corrupt|invalid`)
+)
+
+// FailedOutcome classifies the provider's message for an upload which did not succeed
+//
+// Duplicate files are UploadDuplicate, with the id of the existing activity if the message
+// includes it, files which could not be parsed are UploadInvalid, and all others UploadFailed.
+func FailedOutcome(message string) *UploadOutcome {
+	if m := duplicateRE.FindStringSubmatch(message); m != nil {
+		id, _ := strconv.ParseInt(m[1], 10, 64)
+		return &UploadOutcome{Status: UploadDuplicate, ActivityID: id, Message: message}
+	}
+	if invalidRE.MatchString(message) {
+		return &UploadOutcome{Status: UploadInvalid, Message: message}
+	}
+	return &UploadOutcome{Status: UploadFailed, Message: message}
+}
+
 // Poll is the result of polling
 type Poll struct {
+	// ID is the id of the polled upload
+	ID UploadID
 	// Upload is the upload status if no error occurred
 	Upload Upload
 	// Err is non-nil when an error occurred in the operation but not semantically
@@ -58,12 +132,38 @@ type Uploader interface {
 	Status(ctx context.Context, id UploadID) (Upload, error)
 }
 
+// BatchUploader is implemented by Uploaders which can check the status of many uploads in one request
+type BatchUploader interface {
+	Uploader
+	// Statuses returns the processing status of the files
+	//
+	// The order of the statuses is not guaranteed to match the order of the ids.
+	Statuses(ctx context.Context, ids ...UploadID) ([]Upload, error)
+}
+
 // File for uploading and exporting
 type File struct {
 	io.Reader `json:"-"`
-	Filename  string `json:"filename,omitempty"`
-	Name      string `json:"name"`
-	Format    Format `json:"format"`
+	Filename  string          `json:"filename,omitempty"`
+	Name      string          `json:"name"`
+	Format    Format          `json:"format"`
+	Metadata  *UploadMetadata `json:"metadata,omitempty"`
+}
+
+// UploadMetadata describes the activity created by uploading a file
+//
+// Providers ignore the fields they do not support.
+type UploadMetadata struct {
+	// Name of the activity
+	Name string `json:"name,omitempty"`
+	// Description of the activity
+	Description string `json:"description,omitempty"`
+	// ExternalID identifies the file so the provider can reject a repeated upload as a duplicate
+	ExternalID string `json:"external_id,omitempty"`
+	// Trainer is whether the activity was completed on a trainer, nil if unknown
+	Trainer *bool `json:"trainer,omitempty"`
+	// Commute is whether the activity was a commute, nil if unknown
+	Commute *bool `json:"commute,omitempty"`
 }
 
 // Close the reader (if supported)
@@ -142,8 +242,30 @@ type Poller interface {
 	Poll(ctx context.Context, uploadID UploadID) <-chan *Poll
 }
 
+// MultiPoller will continually check the status of many upload requests
+type MultiPoller interface {
+	// PollAll polls the status of the uploads
+	//
+	// Unlike Poll only the final status of each upload is sent, either once it is done
+	//  or an error occurred checking its status. The operation will continue until all
+	//  the uploads are completed, the context is canceled, or the maximum number of
+	//  iterations have been exceeded in which case ErrExceededIterations is sent for each
+	//  pending upload. If the uploader is a BatchUploader the status of all pending uploads
+	//  is checked in a single request for each iteration.
+	PollAll(ctx context.Context, uploadIDs ...UploadID) <-chan *Poll
+}
+
 // NewPoller returns an instance of a Poller
 func NewPoller(uploader Uploader, opts ...PollerOption) Poller {
+	return newPoller(uploader, opts...)
+}
+
+// NewMultiPoller returns an instance of a MultiPoller
+func NewMultiPoller(uploader Uploader, opts ...PollerOption) MultiPoller {
+	return newPoller(uploader, opts...)
+}
+
+func newPoller(uploader Uploader, opts ...PollerOption) *poller {
 	p := &poller{uploader: uploader, interval: pollInterval, iterations: pollIterations}
 	for _, opt := range opts {
 		opt(p)
@@ -163,7 +285,7 @@ func (p *poller) Poll(ctx context.Context, uploadID UploadID) <-chan *Poll {
 		defer close(res)
 		for i := p.iterations; i > 0; i-- {
 			upload, err := p.uploader.Status(ctx, uploadID)
-			poll := &Poll{Upload: upload, Err: err}
+			poll := &Poll{ID: uploadID, Upload: upload, Err: err}
 			select {
 			case <-ctx.Done():
 				return
@@ -182,8 +304,82 @@ func (p *poller) Poll(ctx context.Context, uploadID UploadID) <-chan *Poll {
 		select {
 		case <-ctx.Done():
 			return
-		case res <- &Poll{Err: ErrExceededIterations}:
+		case res <- &Poll{ID: uploadID, Err: ErrExceededIterations}:
 		}
 	}()
 	return res
 }
+
+func (p *poller) PollAll(ctx context.Context, uploadIDs ...UploadID) <-chan *Poll {
+	res := make(chan *Poll)
+	go func() {
+		defer close(res)
+		send := func(poll *Poll) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case res <- poll:
+				return true
+			}
+		}
+		pending := append([]UploadID(nil), uploadIDs...)
+		for i := p.iterations; i > 0; i-- {
+			polls := p.statuses(ctx, pending)
+			pending = pending[:0]
+			for _, poll := range polls {
+				if poll.Err == nil && !poll.Upload.Done() {
+					pending = append(pending, poll.ID)
+					continue
+				}
+				if !send(poll) {
+					return
+				}
+			}
+			if len(pending) == 0 {
+				return
+			}
+			// wait for a bit to let the processing continue
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(p.interval):
+			}
+		}
+		for _, id := range pending {
+			if !send(&Poll{ID: id, Err: ErrExceededIterations}) {
+				return
+			}
+		}
+	}()
+	return res
+}
+
+// statuses returns the status of each upload, in a single request if the uploader supports it
+func (p *poller) statuses(ctx context.Context, uploadIDs []UploadID) []*Poll {
+	polls := make([]*Poll, len(uploadIDs))
+	batch, ok := p.uploader.(BatchUploader)
+	if !ok {
+		for i, id := range uploadIDs {
+			upload, err := p.uploader.Status(ctx, id)
+			polls[i] = &Poll{ID: id, Upload: upload, Err: err}
+		}
+		return polls
+	}
+	uploads, err := batch.Statuses(ctx, uploadIDs...)
+	byID := make(map[UploadID]Upload, len(uploads))
+	for _, upload := range uploads {
+		byID[upload.Identifier()] = upload
+	}
+	for i, id := range uploadIDs {
+		upload, ok := byID[id]
+		switch {
+		case err != nil:
+			polls[i] = &Poll{ID: id, Err: err}
+		case !ok:
+			polls[i] = &Poll{ID: id, Err: fmt.Errorf("missing status for upload %d", id)}
+		default:
+			polls[i] = &Poll{ID: id, Upload: upload}
+		}
+	}
+	return polls
+}
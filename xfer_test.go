@@ -90,6 +90,93 @@ func TestPoller(t *testing.T) {
 	}
 }
 
+type batchUploader struct {
+	uploader
+	calls int
+}
+
+func (u *batchUploader) Statuses(_ context.Context, ids ...activity.UploadID) ([]activity.Upload, error) {
+	u.calls++
+	if u.err {
+		return nil, errors.New("uploader error")
+	}
+	var uploads []activity.Upload
+	for _, id := range ids {
+		// each upload completes after as many iterations as its id
+		uploads = append(uploads, &task{id: id, done: int(id) <= u.calls})
+	}
+	return uploads, nil
+}
+
+type task struct {
+	id   activity.UploadID
+	done bool
+}
+
+func (t *task) Identifier() activity.UploadID {
+	return t.id
+}
+
+func (t *task) Done() bool {
+	return t.done
+}
+
+func TestMultiPoller(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name     string
+		uploader activity.Uploader
+		ids      []activity.UploadID
+		done     []activity.UploadID
+		exceeded []activity.UploadID
+		failed   []activity.UploadID
+	}{
+		{
+			name:     "batch",
+			uploader: &batchUploader{},
+			ids:      []activity.UploadID{1, 2, 5},
+			done:     []activity.UploadID{1, 2},
+			exceeded: []activity.UploadID{5},
+		},
+		{
+			name:     "batch errors",
+			uploader: &batchUploader{uploader: uploader{err: true}},
+			ids:      []activity.UploadID{1, 2},
+			failed:   []activity.UploadID{1, 2},
+		},
+		{
+			name:     "single",
+			uploader: &uploader{status: 2},
+			ids:      []activity.UploadID{7},
+			done:     []activity.UploadID{7},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var done, exceeded, failed []activity.UploadID
+			p := activity.NewMultiPoller(tt.uploader, activity.WithInterval(time.Millisecond), activity.WithIterations(3))
+			for x := range p.PollAll(context.Background(), tt.ids...) {
+				switch {
+				case errors.Is(x.Err, activity.ErrExceededIterations):
+					exceeded = append(exceeded, x.ID)
+				case x.Err != nil:
+					failed = append(failed, x.ID)
+				default:
+					a.True(x.Upload.Done())
+					done = append(done, x.ID)
+				}
+			}
+			a.Equal(tt.done, done)
+			a.Equal(tt.exceeded, exceeded)
+			a.Equal(tt.failed, failed)
+		})
+	}
+}
+
 func TestFormat(t *testing.T) {
 	t.Parallel()
 	a := assert.New(t)
@@ -118,3 +205,42 @@ func TestFile(t *testing.T) {
 	f = activity.File{}
 	a.NoError(f.Close())
 }
+
+func TestOutcome(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	// uploads which do not classify their outcome
+	a.Equal(activity.UploadPending, activity.Outcome(&upload{done: false}).Status)
+	a.Equal(activity.UploadFailed, activity.Outcome(&upload{done: true}).Status)
+}
+
+func TestFailedOutcome(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		message    string
+		status     activity.UploadStatus
+		activityID int64
+	}{
+		{message: "LongHike.gpx duplicate of <a href='/activities/4567' target='_blank'>Long Hike</a>",
+			status: activity.UploadDuplicate, activityID: 4567},
+		{message: "LongHike.gpx duplicate of activity 4568", status: activity.UploadDuplicate, activityID: 4568},
+		{message: "Duplicate of trip 9876", status: activity.UploadDuplicate, activityID: 9876},
+		{message: "duplicate trip", status: activity.UploadDuplicate},
+		{message: "Error parsing file.", status: activity.UploadInvalid},
+		{message: "Unsupported file type", status: activity.UploadInvalid},
+		{message: "There was an error processing your activity.", status: activity.UploadFailed},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.message, func(t *testing.T) {
+			t.Parallel()
+			outcome := activity.FailedOutcome(tt.message)
+			a.Equal(tt.status, outcome.Status)
+			a.Equal(tt.activityID, outcome.ActivityID)
+			a.Equal(tt.message, outcome.Message)
+		})
+	}
+}
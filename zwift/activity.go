@@ -105,21 +105,17 @@ func (s *ActivityService) ExportActivity(ctx context.Context, act *Activity) (*a
 	if err != nil {
 		return nil, err
 	}
-	res, err := s.client.client.Do(req)
+	res, err := activity.Send(s.client.client, req)
 	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			return nil, err
-		}
+		return nil, err
 	}
 	defer res.Body.Close()
 	if res.StatusCode >= http.StatusBadRequest {
+		fault := newFault(res)
 		if res.StatusCode == http.StatusNotFound {
-			return nil, &Fault{Message: "activity not found"}
+			fault.Message = "activity not found"
 		}
-		return nil, &Fault{Message: fmt.Sprintf("error code: %d", res.StatusCode)}
+		return nil, fault
 	}
 	out := &bytes.Buffer{}
 	_, err = io.Copy(out, res.Body)
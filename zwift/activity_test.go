@@ -3,8 +3,11 @@ package zwift_test
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -112,3 +115,70 @@ func TestActivities(t *testing.T) {
 		})
 	}
 }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestExportActivity(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name       string
+		status     int
+		header     http.Header
+		err        error
+		message    string
+		retryAfter time.Duration
+	}{
+		{
+			name:    "not found",
+			status:  http.StatusNotFound,
+			err:     activity.ErrNotFound,
+			message: "activity not found",
+		},
+		{
+			name:    "forbidden",
+			status:  http.StatusForbidden,
+			err:     activity.ErrUnauthorized,
+			message: "Forbidden",
+		},
+		{
+			name:       "rate limited",
+			status:     http.StatusTooManyRequests,
+			header:     http.Header{"Retry-After": []string{"30"}},
+			err:        activity.ErrRateLimited,
+			message:    "Too Many Requests",
+			retryAfter: 30 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				a.Equal("bucket.s3.amazonaws.com", req.URL.Host)
+				return &http.Response{
+					StatusCode: tt.status,
+					Header:     tt.header,
+					Body:       io.NopCloser(strings.NewReader("<Error><Code>NoSuchKey</Code></Error>")),
+					Request:    req,
+				}, nil
+			})
+			client, err := zwift.NewClient(zwift.WithTransport(transport))
+			a.NoError(err)
+			exp, err := client.Activity.ExportActivity(
+				context.Background(), &zwift.Activity{FitFileBucket: "bucket", FitFileKey: "key"})
+			a.Nil(exp)
+			a.ErrorIs(err, tt.err)
+			var fault *zwift.Fault
+			a.ErrorAs(err, &fault)
+			a.Equal(tt.status, fault.HTTPStatus())
+			a.Equal(tt.message, fault.Error())
+			a.Equal(tt.retryAfter, fault.RetryAfter())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package zwift
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"strings"
@@ -19,13 +20,26 @@ type expiresin struct {
 	Expiry int `json:"expires_in,omitempty"`
 }
 
+// Refresh acquires a token using the username and password
 func (s *AuthService) Refresh(ctx context.Context, username, password string) (*oauth2.Token, error) {
-	values := url.Values{
+	return s.token(ctx, url.Values{
 		"grant_type": {"password"},
 		"username":   {username},
 		"password":   {password},
 		"client_id":  {"Zwift_Mobile_Link"},
-	}
+	})
+}
+
+// RefreshToken acquires a token using the refresh token of a previously acquired token
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*oauth2.Token, error) {
+	return s.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {"Zwift_Mobile_Link"},
+	})
+}
+
+func (s *AuthService) token(ctx context.Context, values url.Values) (*oauth2.Token, error) {
 	endpoint := s.client.config.Endpoint
 	body := strings.NewReader(values.Encode())
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.TokenURL, body)
@@ -39,6 +53,11 @@ func (s *AuthService) Refresh(ctx context.Context, username, password string) (*
 	if err = s.client.do(req, &token); err != nil {
 		return nil, err
 	}
-	token.Token.Expiry = time.Now().Add(time.Duration(token.Expiry) * time.Second)
+	if token == nil || token.AccessToken == "" {
+		return nil, errors.New("missing access token in response")
+	}
+	if token.Expiry > 0 {
+		token.Token.Expiry = time.Now().Add(time.Duration(token.Expiry) * time.Second)
+	}
 	return &token.Token, nil
 }
@@ -2,6 +2,8 @@ package zwift
 
 import (
 	"time"
+
+	"github.com/bzimmer/activity"
 )
 
 // dateTimeFormat used by cyclinganalytics
@@ -9,6 +11,7 @@ const datetimeFormat = `"2006-01-02T15:04:05+0000"`
 
 // Fault represents a Zwift error
 type Fault struct { //nolint:errname // convention
+	activity.FaultStatus
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
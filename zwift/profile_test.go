@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bzimmer/activity"
 	"github.com/bzimmer/activity/zwift"
 )
 
@@ -54,3 +55,22 @@ func TestProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestProfileNotFound(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/profiles/missing", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	client, svr := newClient(t, mux)
+	defer svr.Close()
+
+	profile, err := client.Profile.Profile(context.Background(), "missing")
+	a.Nil(profile)
+	a.ErrorIs(err, activity.ErrNotFound)
+	a.NotErrorIs(err, activity.ErrUnauthorized)
+	_, ok := activity.RetryAfter(err)
+	a.False(ok)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,11 +15,18 @@ import (
 	"github.com/bzimmer/activity"
 )
 
-//go:generate genwith --do --client --token --ratelimit --config --endpoint-func --package zwift
+//go:generate genwith --client --token --ratelimit --config --endpoint-func --package zwift
 
 const _baseURL = "https://us-or-rly101.zwift.com"
 const userAgent = "CNL/3.4.1 (Darwin Kernel 20.3.0) zwift/1.0.61590 curl/7.64.1"
 
+const (
+	// authTimeout is the default timeout for acquiring a token
+	authTimeout = 10 * time.Second
+	// expiryDelta is the default duration before expiry at which a token is refreshed
+	expiryDelta = time.Minute
+)
+
 // Endpoint is Zwifts's OAuth 2.0 endpoint
 func Endpoint() oauth2.Endpoint {
 	return oauth2.Endpoint{
@@ -36,6 +44,9 @@ type Client struct {
 	username string
 	password string
 
+	authTimeout time.Duration
+	expiryDelta time.Duration
+
 	lock sync.RWMutex
 
 	Auth     *AuthService
@@ -56,6 +67,12 @@ func withServices() Option {
 		if c.baseURL == "" {
 			c.baseURL = _baseURL
 		}
+		if c.authTimeout == 0 {
+			c.authTimeout = authTimeout
+		}
+		if c.expiryDelta == 0 {
+			c.expiryDelta = expiryDelta
+		}
 		c.lock = sync.RWMutex{}
 		return nil
 	}
@@ -70,6 +87,9 @@ func WithBaseURL(baseURL string) Option {
 }
 
 // WithTokenRefresh refreshes the access token if none is provided
+//
+// The username and password are used only if no refresh token is available or if
+// refreshing the access token with the refresh token fails.
 func WithTokenRefresh(username, password string) Option {
 	return func(c *Client) error {
 		c.username = username
@@ -78,32 +98,103 @@ func WithTokenRefresh(username, password string) Option {
 	}
 }
 
-func (c *Client) validateToken(ctx context.Context) error {
-	c.lock.RLock()
-	// if no access token try to acquire one
-	if c.token != nil && c.token.AccessToken != "" {
-		c.lock.RUnlock()
+// WithAuthTimeout sets the timeout for acquiring or refreshing a token
+func WithAuthTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return errors.New("auth timeout must be positive")
+		}
+		c.authTimeout = timeout
+		return nil
+	}
+}
+
+// WithExpiryDelta sets how long before the token expires it is proactively refreshed
+func WithExpiryDelta(delta time.Duration) Option {
+	return func(c *Client) error {
+		if delta < 0 {
+			return errors.New("expiry delta must not be negative")
+		}
+		c.expiryDelta = delta
 		return nil
 	}
+}
+
+// accessToken returns a valid access token
+//
+// The token is refreshed if it is missing, about to expire, or equal to `stale`, the
+// access token of a request which was rejected as unauthorized. Concurrent callers wait
+// for a single refresh.
+func (c *Client) accessToken(ctx context.Context, stale string) (string, error) {
+	c.lock.RLock()
+	token, ok := c.valid(stale)
 	c.lock.RUnlock()
-	// bail if username and password are not available
-	if c.username == "" || c.password == "" {
-		return errors.New("accessToken required")
+	if ok {
+		return token, nil
 	}
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	// another caller may have refreshed the token while waiting for the lock
+	if token, ok = c.valid(stale); ok {
+		return token, nil
+	}
+	if !c.refreshable() {
+		if c.token != nil && c.token.AccessToken != "" && c.token.AccessToken != stale {
+			// the token cannot be refreshed so use it as is and let the api decide
+			return c.token.AccessToken, nil
+		}
+		return "", errors.New("accessToken required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.authTimeout)
 	defer cancel()
-	token, err := c.Auth.Refresh(ctx, c.username, c.password)
+	t, err := c.refresh(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
-	c.token = token
-	return nil
+	if t.RefreshToken == "" && c.token != nil {
+		t.RefreshToken = c.token.RefreshToken
+	}
+	c.token = t
+	return t.AccessToken, nil
+}
+
+// valid returns the access token if it is present, not expiring, and not stale
+//
+// The caller must hold the lock.
+func (c *Client) valid(stale string) (string, bool) {
+	if c.token == nil || c.token.AccessToken == "" || c.token.AccessToken == stale {
+		return "", false
+	}
+	if !c.token.Expiry.IsZero() && time.Now().Add(c.expiryDelta).After(c.token.Expiry) {
+		return "", false
+	}
+	return c.token.AccessToken, true
+}
+
+// refreshable returns true if a token can be acquired
+//
+// The caller must hold the lock.
+func (c *Client) refreshable() bool {
+	return (c.token != nil && c.token.RefreshToken != "") || (c.username != "" && c.password != "")
+}
+
+// refresh acquires a new token preferring the refresh token over the password
+//
+// The caller must hold the write lock.
+func (c *Client) refresh(ctx context.Context) (*oauth2.Token, error) {
+	if c.token != nil && c.token.RefreshToken != "" {
+		token, err := c.Auth.RefreshToken(ctx, c.token.RefreshToken)
+		if err == nil || c.username == "" || c.password == "" {
+			return token, err
+		}
+		// the refresh token may have expired or been revoked so fall back to the password
+	}
+	return c.Auth.Refresh(ctx, c.username, c.password)
 }
 
 func (c *Client) newAPIRequest(ctx context.Context, method, uri string) (*http.Request, error) {
-	if err := c.validateToken(ctx); err != nil {
+	token, err := c.accessToken(ctx, "")
+	if err != nil {
 		return nil, err
 	}
 	u, err := url.Parse(fmt.Sprintf("%s/%s", c.baseURL, uri))
@@ -116,6 +207,64 @@ func (c *Client) newAPIRequest(ctx context.Context, method, uri string) (*http.R
 	}
 	req.Header.Set("User-Agent", userAgent)
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", c.token.AccessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
 	return req, nil
 }
+
+// do executes the http request and populates v with the result
+//
+// A response with an error status is returned as a *Fault. If an api request is
+// unauthorized the token is refreshed, if possible, and the request retried once.
+func (c *Client) do(req *http.Request, v any) error {
+	res, err := activity.Send(c.client, req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == http.StatusUnauthorized && c.retryable(req) {
+		fault := newFault(res)
+		res.Body.Close()
+		if req, err = c.reauthorize(req); err != nil {
+			return errors.Join(fault, err)
+		}
+		if res, err = activity.Send(c.client, req); err != nil {
+			return err
+		}
+	}
+	defer res.Body.Close()
+	return activity.Decode(res, v, newFault)
+}
+
+// retryable returns true if the request is a bodiless api request with a token which can be refreshed
+func (c *Client) retryable(req *http.Request) bool {
+	if req.Header.Get("Authorization") == "" || (req.Body != nil && req.Body != http.NoBody) {
+		return false
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.refreshable()
+}
+
+// reauthorize returns a clone of the request authorized with a refreshed token
+func (c *Client) reauthorize(req *http.Request) (*http.Request, error) {
+	stale := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	token, err := c.accessToken(req.Context(), stale)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+	return req, nil
+}
+
+// newFault returns a Fault from the error response
+func newFault(res *http.Response) *Fault {
+	fault := &Fault{}
+	fault.FaultStatus = activity.DecodeFault(res, fault)
+	if fault.Code == 0 {
+		fault.Code = res.StatusCode
+	}
+	if fault.Message == "" {
+		fault.Message = http.StatusText(res.StatusCode)
+	}
+	return fault
+}
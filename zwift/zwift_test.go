@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2"
 
+	"github.com/bzimmer/activity"
 	"github.com/bzimmer/activity/zwift"
 )
 
@@ -105,3 +107,142 @@ func TestTokenRefresh(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenExpiry(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var mu sync.Mutex
+	var grants []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		a.NoError(r.ParseForm())
+		mu.Lock()
+		grants = append(grants, r.Form.Get("grant_type"))
+		mu.Unlock()
+		switch r.Form.Get("grant_type") {
+		case "refresh_token":
+			a.Equal("bar", r.Form.Get("refresh_token"))
+			a.Empty(r.Form.Get("password"))
+			_, _ = w.Write([]byte(`{"access_token":"refreshed","expires_in":3600}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+	mux.HandleFunc("/api/profiles/me", func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("Bearer refreshed", r.Header.Get("Authorization"))
+		a.NoError(json.NewEncoder(w).Encode(&zwift.Profile{FirstName: "barney"}))
+	})
+
+	// the token expires within the expiry delta so is refreshed before the request
+	client, svr := newClient(t, mux,
+		zwift.WithTokenCredentials("foo", "bar", time.Now().Add(time.Second*30)),
+		zwift.WithTokenRefresh("foo-user", "bar-pass"))
+	defer svr.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profile, err := client.Profile.Profile(context.Background(), zwift.Me)
+			a.NoError(err)
+			a.Equal("barney", profile.FirstName)
+		}()
+	}
+	wg.Wait()
+	a.Equal([]string{"refresh_token"}, grants)
+}
+
+func TestTokenRetry(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tests := []struct {
+		name    string
+		opts    []zwift.Option
+		token   int
+		profile int
+		err     bool
+	}{
+		{
+			name:    "refreshed after unauthorized",
+			token:   http.StatusOK,
+			profile: 2,
+		},
+		{
+			name:    "refresh fails",
+			token:   http.StatusBadRequest,
+			profile: 1,
+			err:     true,
+		},
+		{
+			name:    "no refresh token",
+			opts:    []zwift.Option{zwift.WithTokenCredentials("foo", "", time.Time{})},
+			profile: 1,
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var profile int
+			mux := http.NewServeMux()
+			mux.HandleFunc("/token", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.token)
+				_, _ = w.Write([]byte(`{"access_token":"refreshed","expires_in":3600}`))
+			})
+			mux.HandleFunc("/api/profiles/me", func(w http.ResponseWriter, r *http.Request) {
+				profile++
+				if r.Header.Get("Authorization") != "Bearer refreshed" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				a.NoError(json.NewEncoder(w).Encode(&zwift.Profile{FirstName: "barney"}))
+			})
+			client, svr := newClient(t, mux, tt.opts...)
+			defer svr.Close()
+
+			p, err := client.Profile.Profile(context.Background(), zwift.Me)
+			a.Equal(tt.profile, profile)
+			if tt.err {
+				a.ErrorIs(err, activity.ErrUnauthorized)
+				a.Nil(p)
+				return
+			}
+			a.NoError(err)
+			a.Equal("barney", p.FirstName)
+		})
+	}
+}
+
+func TestAuthTimeout(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client, svr := newClient(t, mux,
+		zwift.WithTokenCredentials("", "", time.Time{}),
+		zwift.WithTokenRefresh("foo-user", "bar-pass"),
+		zwift.WithAuthTimeout(time.Millisecond*10))
+	defer svr.Close()
+	defer close(release)
+
+	profile, err := client.Profile.Profile(context.Background(), zwift.Me)
+	a.ErrorIs(err, context.DeadlineExceeded)
+	a.Nil(profile)
+
+	_, err = zwift.NewClient(zwift.WithAuthTimeout(0))
+	a.Error(err)
+	_, err = zwift.NewClient(zwift.WithExpiryDelta(-time.Second))
+	a.Error(err)
+}